@@ -1,17 +1,17 @@
 package benchmark
 
 import (
+	"context"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	buildAnalyser "github.com/ionut-t/gonx/benchmark/build-analyser"
-	buildAnalyserHistory "github.com/ionut-t/gonx/benchmark/build-analyser-history"
 	bundleAnalyser "github.com/ionut-t/gonx/benchmark/bundle-analyser"
 	bundleAnalyserHistory "github.com/ionut-t/gonx/benchmark/bundle-analyser-history"
-	lintAnalyser "github.com/ionut-t/gonx/benchmark/lint-analyser"
-	lintAnalyserHistory "github.com/ionut-t/gonx/benchmark/lint-analyser-history"
+	"github.com/ionut-t/gonx/benchmark/suite"
+	suiteRunner "github.com/ionut-t/gonx/benchmark/suite-runner"
 	testsAnalyser "github.com/ionut-t/gonx/benchmark/tests-analyser"
 	testsAnalyserHistory "github.com/ionut-t/gonx/benchmark/tests-analyser-history"
+	workspaceAnalyser "github.com/ionut-t/gonx/benchmark/workspace-analyser"
 	"github.com/ionut-t/gonx/internal/keymap"
 	"github.com/ionut-t/gonx/internal/messages"
 	"github.com/ionut-t/gonx/workspace"
@@ -47,6 +47,13 @@ const (
 	lintAnalyserHistoryView
 	testsAnalyserView
 	testsAnalyserHistoryView
+	selectTargetView
+	targetAnalyserView
+	targetAnalyserHistoryView
+	workspaceAnalyserView
+	projectGraphView
+	selectSuiteView
+	suiteRunnerView
 )
 
 var historyViews = []view{
@@ -54,6 +61,7 @@ var historyViews = []view{
 	buildAnalyserHistoryView,
 	lintAnalyserHistoryView,
 	testsAnalyserHistoryView,
+	targetAnalyserHistoryView,
 }
 
 var (
@@ -63,39 +71,66 @@ var (
 type Model struct {
 	view view
 
+	ctx context.Context
+
 	workspace workspace.Model
+	// scanning is set while the workspace is scanned again in the background
+	scanning bool
 
 	taskList     tasksModel
 	projectsList selectProjectsModel
+	projectGraph projectGraphModel
+	// preselected are the projects taken from the project graph into the selected analyser
+	preselected []string
 
 	bundleAnalyser            bundleAnalyser.Model
 	bundleAnalyserHistoryView bundleAnalyserHistory.Model
 
-	buildAnalyser            buildAnalyser.Model
-	buildAnalyserHistoryView buildAnalyserHistory.Model
+	buildAnalyser            testsAnalyser.Model
+	buildAnalyserHistoryView testsAnalyserHistory.Model
 
-	lintAnalyser        lintAnalyser.Model
-	lintAnalyserHistory lintAnalyserHistory.Model
+	lintAnalyser        testsAnalyser.Model
+	lintAnalyserHistory testsAnalyserHistory.Model
 
 	testsAnalyser        testsAnalyser.Model
 	testsAnalyserHistory testsAnalyserHistory.Model
 
+	targetsList           selectTargetModel
+	targetAnalyser        testsAnalyser.Model
+	targetAnalyserHistory testsAnalyserHistory.Model
+
+	workspaceAnalyser workspaceAnalyser.Model
+
+	suitesList  selectSuiteModel
+	suiteRunner suiteRunner.Model
+
 	width  int
 	height int
 }
 
 type Options struct {
+	// Context is cancelled when gonx exits, stopping any benchmark in progress.
+	Context   context.Context
 	Workspace workspace.Model
 	Width     int
 	Height    int
 }
 
 func New(options Options) Model {
+	ctx := options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	taskList := newTasksList(options.Width, options.Height)
+	taskList.setScanning(false, len(options.Workspace.Skipped))
+
 	return Model{
+		ctx:       ctx,
 		width:     options.Width,
 		height:    options.Height,
 		workspace: options.Workspace,
-		taskList:  newTasksList(options.Width, options.Height),
+		taskList:  taskList,
 	}
 }
 
@@ -134,6 +169,27 @@ func (m Model) View() string {
 
 	case testsAnalyserHistoryView:
 		return viewStyle(m.testsAnalyserHistory.View())
+
+	case selectTargetView:
+		return m.targetsList.View()
+
+	case targetAnalyserView:
+		return viewStyle(m.targetAnalyser.View())
+
+	case targetAnalyserHistoryView:
+		return m.targetAnalyserHistory.View()
+
+	case workspaceAnalyserView:
+		return viewStyle(m.workspaceAnalyser.View())
+
+	case projectGraphView:
+		return m.projectGraph.View()
+
+	case selectSuiteView:
+		return m.suitesList.View()
+
+	case suiteRunnerView:
+		return viewStyle(m.suiteRunner.View())
 	}
 
 	return ""
@@ -154,90 +210,162 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keymap.Quit):
 			return m, tea.Quit
 
-		case key.Matches(msg, keymap.BuildAnalyserHistory):
-			if (m.view == selectTasksView || m.isHistoryView()) && !m.bundleAnalyserHistoryView.Searching() {
+		case key.Matches(msg, keymap.Rescan):
+			if m.view == selectTasksView && !m.scanning {
+				return m, messages.Dispatch(workspace.RescanMsg{})
+			}
+
+		case key.Matches(msg, keymap.BundleAnalyserHistory):
+			if m.view == selectTasksView || m.isHistoryView() && !m.historySearching() {
 				m.view = bundleAnalyserHistoryView
 				m.bundleAnalyserHistoryView = bundleAnalyserHistory.New(m.width, m.height)
 			}
 
 		case key.Matches(msg, keymap.BuildAnalyserHistory):
-			if m.view == selectTasksView || m.isHistoryView() && !m.buildAnalyserHistoryView.Searching() {
+			if m.view == selectTasksView || m.isHistoryView() && !m.historySearching() {
 				m.view = buildAnalyserHistoryView
-				m.buildAnalyserHistoryView = buildAnalyserHistory.New(m.width, m.height)
+				m.buildAnalyserHistoryView = testsAnalyserHistory.New(testsAnalyserHistory.Build, m.width, m.height)
 			}
 
 		case key.Matches(msg, keymap.LintAnalyserHistory):
-			if m.view == selectTasksView || m.isHistoryView() && !m.lintAnalyserHistory.Searching() {
+			if m.view == selectTasksView || m.isHistoryView() && !m.historySearching() {
 				m.view = lintAnalyserHistoryView
-				m.lintAnalyserHistory = lintAnalyserHistory.New(m.width, m.height)
+				m.lintAnalyserHistory = testsAnalyserHistory.New(testsAnalyserHistory.Lint, m.width, m.height)
 			}
 
 		case key.Matches(msg, keymap.TestsAnalyserHistory):
-			if m.view == selectTasksView || m.isHistoryView() && !m.testsAnalyserHistory.Searching() {
+			if m.view == selectTasksView || m.isHistoryView() && !m.historySearching() {
 				m.view = testsAnalyserHistoryView
-				m.testsAnalyserHistory = testsAnalyserHistory.New(m.width, m.height)
+				m.testsAnalyserHistory = testsAnalyserHistory.New(testsAnalyserHistory.Tests, m.width, m.height)
+			}
+
+		case key.Matches(msg, keymap.TargetAnalyserHistory):
+			if m.view == selectTasksView || m.isHistoryView() && !m.historySearching() {
+				m.view = targetAnalyserHistoryView
+				m.targetAnalyserHistory = testsAnalyserHistory.New(testsAnalyserHistory.Targets, m.width, m.height)
 			}
 		}
 
+	case workspace.RescanMsg:
+		m.scanning = true
+		m.taskList.setScanning(true, len(m.workspace.Skipped))
+
+	case workspace.DoneMsg:
+		m.workspace = msg.Workspace
+		m.scanning = false
+		m.taskList.setScanning(false, len(m.workspace.Skipped))
+
+	case workspace.ErrMsg:
+		// the workspace scanned before is kept when a rescan fails
+		m.scanning = false
+		m.taskList.setScanning(false, len(m.workspace.Skipped))
+
 	case taskMsg:
-		m.view = selectAppsView
+		if m.taskList.selected == projectGraphTask {
+			m.view = projectGraphView
+			m.preselected = nil
+			m.projectGraph = newProjectGraph(m.workspace, m.width, m.height)
+			break
+		}
+
+		if m.taskList.selected == suiteTask {
+			m.view = selectSuiteView
+			m.suitesList = newSuitesList(m.workspace, m.width, m.height)
+			break
+		}
 
-		includedTypes := []workspace.ProjectType{workspace.ApplicationType}
+		if m.taskList.selected == targetAnalyserTask {
+			m.view = selectTargetView
+			m.targetsList = newTargetsList(m.workspace.GetTargets(), m.width, m.height)
+			break
+		}
 
-		if m.taskList.selected == lintAnalyserTask || m.taskList.selected == testsAnalyserTask {
-			includedTypes = append(includedTypes, workspace.LibraryType)
+		if m.taskList.selected == workspaceAnalyserTask {
+			m.view = workspaceAnalyserView
+			m.workspaceAnalyser = workspaceAnalyser.New(
+				m.ctx,
+				m.workspace.GetTargets(),
+				m.taskList.selected.projects(m.workspace),
+				m.preselected,
+				m.width,
+				m.height,
+			)
+			return m, m.workspaceAnalyser.Init()
 		}
 
+		m.view = selectAppsView
+
 		options := projectsListOptions{
 			width:       m.width,
 			height:      m.height,
-			projects:    m.workspace.GetProjects(includedTypes),
+			projects:    m.taskList.selected.projects(m.workspace),
 			displayType: m.taskList.selected == lintAnalyserTask || m.taskList.selected == testsAnalyserTask,
+			target:      m.taskList.selected.target(),
+			selected:    m.preselected,
 		}
 
 		m.projectsList = newSelectionList(options)
 
+	case targetSelectedMsg:
+		m.view = selectAppsView
+		m.projectsList = newSelectionList(projectsListOptions{
+			width:       m.width,
+			height:      m.height,
+			projects:    m.workspace.GetProjectsWithTarget(string(msg)),
+			displayType: true,
+			target:      string(msg),
+			selected:    m.preselected,
+		})
+
+	case suiteSelectedMsg:
+		m.view = suiteRunnerView
+		m.suiteRunner = suiteRunner.New(m.ctx, suite.Run(msg), m.width, m.height)
+		return m, m.suiteRunner.Init()
+
+	case graphSelectionMsg:
+		m.taskList.selected = msg.task
+		m.preselected = msg.projects
+		return m, messages.Dispatch(taskMsg(msg.task))
+
 	case projectsSelectedMsg:
 		switch m.taskList.selected {
 		case bundleAnalyserTask:
 			m.view = bundleAnalyserView
-			var apps = make([]workspace.Application, 0, len(msg))
-			for _, app := range msg {
+			var apps = make([]workspace.Application, 0, len(msg.projects))
+			for _, app := range msg.projects {
 				apps = append(apps, app.(workspace.Application))
 			}
 
-			m.bundleAnalyser = bundleAnalyser.New(apps, m.width, m.height)
+			m.bundleAnalyser = bundleAnalyser.New(m.ctx, apps, msg.configuration, m.width, m.height)
 
 		case buildAnalyserTask:
 			m.view = buildAnalyserView
-			var apps = make([]string, 0, len(msg))
-			for _, app := range msg {
-				apps = append(apps, app.GetName())
-			}
-			m.buildAnalyser = buildAnalyser.New(apps, m.width, m.height)
+			m.buildAnalyser = testsAnalyser.New(m.ctx, testsAnalyser.Build, msg.projects, msg.configuration, m.width, m.height)
 
 		case lintAnalyserTask:
 			m.view = lintAnalyserView
-			m.lintAnalyser = lintAnalyser.New(msg, m.width, m.height)
+			m.lintAnalyser = testsAnalyser.New(m.ctx, testsAnalyser.Lint, msg.projects, msg.configuration, m.width, m.height)
 
 		case testsAnalyserTask:
 			m.view = testsAnalyserView
-			m.testsAnalyser = testsAnalyser.New(msg, m.width, m.height)
+			m.testsAnalyser = testsAnalyser.New(m.ctx, testsAnalyser.Tests, msg.projects, msg.configuration, m.width, m.height)
+
+		case targetAnalyserTask:
+			m.view = targetAnalyserView
+			m.targetAnalyser = testsAnalyser.New(m.ctx, testsAnalyser.Target(m.projectsList.target), msg.projects, msg.configuration, m.width, m.height)
 		}
 
 	case messages.NavigateToViewMsg:
 		if m.view != selectTasksView {
+			if view(msg) == selectTasksView {
+				m.preselected = nil
+			}
+
 			m.view = view(msg)
 			return m, nil
 		}
 	}
 
-	if m.view == selectTasksView {
-		bModel, cmd := m.bundleAnalyser.Update(msg)
-		m.bundleAnalyser = bModel.(bundleAnalyser.Model)
-		cmds = append(cmds, cmd)
-	}
-
 	switch m.view {
 	case selectTasksView:
 		tModel, cmd := m.taskList.Update(msg)
@@ -256,7 +384,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case buildAnalyserView:
 		bModel, cmd := m.buildAnalyser.Update(msg)
-		m.buildAnalyser = bModel.(buildAnalyser.Model)
+		m.buildAnalyser = bModel.(testsAnalyser.Model)
 		cmds = append(cmds, cmd)
 
 	case bundleAnalyserHistoryView:
@@ -266,17 +394,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case buildAnalyserHistoryView:
 		bModel, cmd := m.buildAnalyserHistoryView.Update(msg)
-		m.buildAnalyserHistoryView = bModel.(buildAnalyserHistory.Model)
+		m.buildAnalyserHistoryView = bModel.(testsAnalyserHistory.Model)
 		cmds = append(cmds, cmd)
 
 	case lintAnalyserView:
 		lModel, cmd := m.lintAnalyser.Update(msg)
-		m.lintAnalyser = lModel.(lintAnalyser.Model)
+		m.lintAnalyser = lModel.(testsAnalyser.Model)
 		cmds = append(cmds, cmd)
 
 	case lintAnalyserHistoryView:
 		lModel, cmd := m.lintAnalyserHistory.Update(msg)
-		m.lintAnalyserHistory = lModel.(lintAnalyserHistory.Model)
+		m.lintAnalyserHistory = lModel.(testsAnalyserHistory.Model)
 		cmds = append(cmds, cmd)
 
 	case testsAnalyserView:
@@ -288,6 +416,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		tModel, cmd := m.testsAnalyserHistory.Update(msg)
 		m.testsAnalyserHistory = tModel.(testsAnalyserHistory.Model)
 		cmds = append(cmds, cmd)
+
+	case selectTargetView:
+		tModel, cmd := m.targetsList.Update(msg)
+		m.targetsList = tModel.(selectTargetModel)
+		cmds = append(cmds, cmd)
+
+	case targetAnalyserView:
+		tModel, cmd := m.targetAnalyser.Update(msg)
+		m.targetAnalyser = tModel.(testsAnalyser.Model)
+		cmds = append(cmds, cmd)
+
+	case targetAnalyserHistoryView:
+		tModel, cmd := m.targetAnalyserHistory.Update(msg)
+		m.targetAnalyserHistory = tModel.(testsAnalyserHistory.Model)
+		cmds = append(cmds, cmd)
+
+	case workspaceAnalyserView:
+		wModel, cmd := m.workspaceAnalyser.Update(msg)
+		m.workspaceAnalyser = wModel.(workspaceAnalyser.Model)
+		cmds = append(cmds, cmd)
+
+	case projectGraphView:
+		gModel, cmd := m.projectGraph.Update(msg)
+		m.projectGraph = gModel.(projectGraphModel)
+		cmds = append(cmds, cmd)
+
+	case selectSuiteView:
+		sModel, cmd := m.suitesList.Update(msg)
+		m.suitesList = sModel.(selectSuiteModel)
+		cmds = append(cmds, cmd)
+
+	case suiteRunnerView:
+		sModel, cmd := m.suiteRunner.Update(msg)
+		m.suiteRunner = sModel.(suiteRunner.Model)
+		cmds = append(cmds, cmd)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -296,3 +459,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) isHistoryView() bool {
 	return slices.Contains(historyViews, m.view)
 }
+
+// historySearching reports whether the current history view captures the key presses.
+func (m Model) historySearching() bool {
+	switch m.view {
+	case bundleAnalyserHistoryView:
+		return m.bundleAnalyserHistoryView.Searching()
+	case buildAnalyserHistoryView:
+		return m.buildAnalyserHistoryView.Searching()
+	case lintAnalyserHistoryView:
+		return m.lintAnalyserHistory.Searching()
+	case testsAnalyserHistoryView:
+		return m.testsAnalyserHistory.Searching()
+	case targetAnalyserHistoryView:
+		return m.targetAnalyserHistory.Searching()
+	}
+
+	return false
+}
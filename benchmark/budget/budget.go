@@ -0,0 +1,208 @@
+package budget
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/internal/constants"
+	"github.com/ionut-t/gonx/workspace"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Analyser is the benchmark a budget applies to, named like the analysers of the suites.
+type Analyser string
+
+const (
+	BundleAnalyser Analyser = "bundle"
+	BuildAnalyser  Analyser = "build"
+	LintAnalyser   Analyser = "lint"
+	TestAnalyser   Analyser = "test"
+	// TargetAnalyser budgets any other target, named by the target of the budget.
+	TargetAnalyser Analyser = "target"
+)
+
+var analysers = []Analyser{BundleAnalyser, BuildAnalyser, LintAnalyser, TestAnalyser, TargetAnalyser}
+
+// size matches the sizes of the budgets, e.g. 500kb, 1.5 MB or 10% of a baseline.
+var size = regexp.MustCompile(`(?i)^(\d+(?:\.\d+)?)[ \t]*(%|[kmg]?b)?$`)
+
+// Budget is a performance budget of the gonx configuration, e.g. the average duration of the builds of shell
+// or the p95 duration of the lint of libs/core/*.
+type Budget struct {
+	Analyser Analyser `json:"analyser"`
+	// Target is the target limited by a budget of the target analyser, e.g. e2e.
+	Target string `json:"target,omitempty"`
+	// Projects are names or globs matched against the name and the folder of the projects, e.g. libs/core/*.
+	// The budget applies to all the projects of the analyser when empty.
+	Projects []string          `json:"projects,omitempty"`
+	Metric   data.BudgetMetric `json:"metric"`
+	// Max is the limit of the metric, a duration such as 90s or 1m30s, or a size such as 500kb for the size buckets.
+	Max string `json:"max"`
+}
+
+// config is the part of .gonx/config.json holding the budgets, next to the suites.
+type config struct {
+	Budgets []Budget `json:"budgets"`
+}
+
+// Budgets returns the budgets of the configuration, none when there is no configuration file.
+func Budgets() ([]Budget, error) {
+	content, err := os.ReadFile(constants.ConfigFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var config config
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("%s: %v", constants.ConfigFilePath, err)
+	}
+
+	for i, budget := range config.Budgets {
+		if err := budget.validate(); err != nil {
+			return nil, fmt.Errorf("%s: budget %d: %v", constants.ConfigFilePath, i+1, err)
+		}
+	}
+
+	return config.Budgets, nil
+}
+
+// CheckDurations checks the budgets of the analyser that apply to the target of the project against the statistics
+// of its durations. The budgets are read once for the whole benchmark, see Budgets.
+func CheckDurations(budgets []Budget, analyser Analyser, target string, project workspace.Project, summary data.Summary) data.BudgetChecks {
+	// the summary of a benchmark whose runs all failed is empty, there is nothing to check
+	if summary == (data.Summary{}) {
+		return nil
+	}
+
+	return check(budgets, analyser, target, project, summary.Metric)
+}
+
+// CheckSizes checks the budgets of the bundle analyser that apply to the app against its size buckets.
+func CheckSizes(budgets []Budget, app workspace.Project, stats data.BuildStats) data.BudgetChecks {
+	return check(budgets, BundleAnalyser, "build", app, func(metric data.BudgetMetric) (float64, bool) {
+		value, ok := stats.Metric(metric)
+		return float64(value), ok
+	})
+}
+
+func check(budgets []Budget, analyser Analyser, target string, project workspace.Project, value func(data.BudgetMetric) (float64, bool)) data.BudgetChecks {
+	var checks data.BudgetChecks
+
+	for _, budget := range budgets {
+		if !budget.appliesTo(analyser, target, project) {
+			continue
+		}
+
+		current, ok := value(budget.Metric)
+		if !ok {
+			continue
+		}
+
+		// validated when the configuration was read
+		limit, _ := budget.limit()
+
+		checks = append(checks, data.BudgetCheck{
+			Metric:   budget.Metric,
+			Projects: budget.Projects,
+			Value:    current,
+			Limit:    limit,
+			Passed:   current <= limit,
+		})
+	}
+
+	return checks
+}
+
+func (b Budget) appliesTo(analyser Analyser, target string, project workspace.Project) bool {
+	if b.Analyser != analyser || b.Analyser == TargetAnalyser && b.Target != target {
+		return false
+	}
+
+	return len(b.Projects) == 0 || slices.ContainsFunc(b.Projects, func(pattern string) bool {
+		return workspace.MatchGlob(pattern, project)
+	})
+}
+
+func (b Budget) validate() error {
+	if !slices.Contains(analysers, b.Analyser) {
+		return fmt.Errorf("analyser must be one of bundle, build, lint, test or target")
+	}
+
+	if b.Analyser == TargetAnalyser && b.Target == "" {
+		return fmt.Errorf("target is required by the target analyser")
+	}
+
+	if b.Analyser == BundleAnalyser && !b.Metric.IsSize() {
+		return fmt.Errorf("metric of the bundle analyser must be one of main, runtime, polyfills, initial, lazy, styles, assets, total or overall")
+	}
+
+	if b.Analyser != BundleAnalyser && !slices.Contains(data.DurationMetrics, b.Metric) {
+		return fmt.Errorf("metric of the %s analyser must be one of min, max, avg, median, p90 or p95", b.Analyser)
+	}
+
+	if b.Max == "" {
+		return fmt.Errorf("max is required")
+	}
+
+	if _, err := b.limit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// limit returns the maximum of the budget, in seconds for the durations and in bytes for the sizes.
+func (b Budget) limit() (float64, error) {
+	if !b.Metric.IsSize() {
+		duration, err := time.ParseDuration(strings.TrimSpace(b.Max))
+		if err != nil || duration <= 0 {
+			return 0, fmt.Errorf("max must be a duration such as 90s or 1m30s, not %q", b.Max)
+		}
+
+		return duration.Seconds(), nil
+	}
+
+	limit, err := ParseSize(b.Max, 0)
+	if err != nil {
+		return 0, fmt.Errorf("max must be a size such as 500kb or 1.5mb, not %q", b.Max)
+	}
+
+	return limit, nil
+}
+
+// ParseSize reads a size such as 500kb or 1.5 MB in bytes, the units being multiples of 1024 like the Angular budgets.
+// A percentage such as 10% is a share of the baseline, which must then be set.
+func ParseSize(value string, baseline float64) (float64, error) {
+	match := size.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("Invalid budget size %q", value)
+	}
+
+	bytes, _ := strconv.ParseFloat(match[1], 64)
+
+	switch strings.ToLower(match[2]) {
+	case "%":
+		if baseline == 0 {
+			return 0, fmt.Errorf("%s is relative to a baseline, which the budget doesn't set", value)
+		}
+		bytes = baseline * bytes / 100
+	case "kb":
+		bytes *= 1024
+	case "mb":
+		bytes *= 1024 * 1024
+	case "gb":
+		bytes *= 1024 * 1024 * 1024
+	}
+
+	return bytes, nil
+}
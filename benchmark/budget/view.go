@@ -0,0 +1,39 @@
+package budget
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/ui/styles"
+)
+
+// Badge renders PASS or FAIL for the checks of a benchmark, empty when no budget applies to it.
+func Badge(checks data.BudgetChecks) string {
+	switch {
+	case len(checks) == 0:
+		return ""
+	case checks.Passed():
+		return styles.Success.Bold(true).Reverse(true).Render(" PASS ")
+	}
+
+	return styles.Error.Bold(true).Reverse(true).Render(" FAIL ")
+}
+
+// Render lists the checks of the performance budgets under their badge, empty when no budget applies to the benchmark.
+func Render(checks data.BudgetChecks) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	lines := []string{styles.NormalText.Render(fmt.Sprintf("%sPerformance budgets: ", styles.IconStyle("🚦"))) + Badge(checks)}
+
+	for _, check := range checks {
+		if check.Passed {
+			lines = append(lines, styles.Success.Render("   ✓ "+check.String()))
+		} else {
+			lines = append(lines, styles.Error.Render("   ✘ "+check.String()))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
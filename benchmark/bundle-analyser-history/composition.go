@@ -0,0 +1,294 @@
+package bundle_analyser_history
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/internal/keymap"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/help"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/utils"
+	"sort"
+)
+
+const compositionTitle = "🧩 Bundle Composition"
+
+// closeCompositionMsg is sent when the user leaves the composition of a benchmark.
+type closeCompositionMsg struct{}
+
+// compositionModel lists the packages the JavaScript of a benchmark was bundled from, the largest first,
+// and the modules of the package picked, each compared with the previous benchmark of the app.
+type compositionModel struct {
+	benchmark data.BundleBenchmark
+	// previous is the benchmark of the same app recorded before, nil when there is none to compare with
+	previous *data.BundleBenchmark
+	// pkg is the package drilled into, empty while the packages are listed
+	pkg string
+	// rows are the packages or the modules listed in the table
+	rows  []compositionRow
+	table table.Model
+	help  help.Model
+
+	width, height int
+}
+
+// compositionRow is a package or a module, with its size in the previous benchmark.
+type compositionRow struct {
+	name     string
+	size     int64
+	previous int64
+	// added and removed tell the packages or modules that are only in one of the benchmarks
+	added, removed bool
+}
+
+func newCompositionModel(benchmark data.BundleBenchmark, previous *data.BundleBenchmark, width, height int) compositionModel {
+	helpMenu := help.New(width, height)
+	helpMenu.SetKeyMap(keymap.Model{
+		Up:     keymap.Up,
+		Down:   keymap.Down,
+		Select: keymap.Select,
+		Back:   keymap.Back,
+		Quit:   keymap.Quit,
+		Help:   keymap.Help,
+	})
+
+	m := compositionModel{
+		benchmark: benchmark,
+		previous:  previous,
+		help:      helpMenu,
+		width:     width,
+		height:    height,
+	}
+
+	m.list("")
+
+	return m
+}
+
+func (m compositionModel) Update(msg tea.Msg) (compositionModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.table = m.createTable()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.help.Keys.Back):
+			if m.pkg != "" {
+				pkg := m.pkg
+				m.list("")
+
+				// back on the package drilled into
+				for i, row := range m.rows {
+					if row.name == pkg {
+						m.table.SetCursor(i)
+					}
+				}
+				return m, nil
+			}
+
+			return m, messages.Dispatch(closeCompositionMsg{})
+
+		case key.Matches(msg, m.help.Keys.Select):
+			if cursor := m.table.Cursor(); m.pkg == "" && cursor >= 0 && cursor < len(m.rows) {
+				m.list(m.rows[cursor].name)
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+
+	helpMenu, helpCmd := m.help.Update(msg)
+	m.help = helpMenu.(help.Model)
+
+	return m, tea.Batch(cmd, helpCmd)
+}
+
+func (m compositionModel) View() string {
+	header := styles.SimpleHeader(m.title(), m.comparedWith())
+
+	if len(m.benchmark.Composition) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			header,
+			lipgloss.NewStyle().Padding(1, 2).Render(
+				styles.DimText.Render("No source maps were found in the output of this benchmark. Build the app with source maps to see what it is made of."),
+			),
+			m.help.View(),
+		)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		tableStyles.Base.Render(m.table.View()),
+		m.help.View(),
+	)
+}
+
+func (m compositionModel) title() string {
+	if m.pkg != "" {
+		return fmt.Sprintf("%s of %s › %s", compositionTitle, m.benchmark.AppName, m.pkg)
+	}
+
+	return fmt.Sprintf("%s of %s", compositionTitle, m.benchmark.AppName)
+}
+
+func (m compositionModel) comparedWith() string {
+	if m.previous == nil {
+		return "No previous benchmark to compare with"
+	}
+
+	return fmt.Sprintf("Compared with %s", m.previous.CreatedAt.Format("02/01/2006 15:04"))
+}
+
+// list fills the table with the packages, or with the modules of pkg.
+func (m *compositionModel) list(pkg string) {
+	m.pkg = pkg
+
+	if pkg == "" {
+		current := make(map[string]int64, len(m.benchmark.Composition))
+		for _, p := range m.benchmark.Composition {
+			current[p.Name] = p.Size
+		}
+
+		var previous map[string]int64
+		if m.previous != nil {
+			previous = make(map[string]int64, len(m.previous.Composition))
+			for _, p := range m.previous.Composition {
+				previous[p.Name] = p.Size
+			}
+		}
+
+		m.rows = compareSizes(current, previous)
+	} else {
+		m.rows = compareSizes(moduleSizes(&m.benchmark, pkg), moduleSizes(m.previous, pkg))
+	}
+
+	m.table = m.createTable()
+}
+
+// moduleSizes returns the sizes of the modules of pkg in the benchmark, nil when there's no benchmark.
+func moduleSizes(benchmark *data.BundleBenchmark, pkg string) map[string]int64 {
+	if benchmark == nil {
+		return nil
+	}
+
+	sizes := map[string]int64{}
+	for _, p := range benchmark.Composition {
+		if p.Name == pkg {
+			for _, module := range p.Modules {
+				sizes[module.Path] = module.Size
+			}
+		}
+	}
+
+	return sizes
+}
+
+// compareSizes lists the entries of current, the largest first, followed by the ones removed since previous.
+// A nil previous compares with nothing.
+func compareSizes(current, previous map[string]int64) []compositionRow {
+	rows := make([]compositionRow, 0, len(current))
+
+	for name, size := range current {
+		before, found := previous[name]
+		rows = append(rows, compositionRow{name: name, size: size, previous: before, added: previous != nil && !found})
+	}
+
+	for name, size := range previous {
+		if _, found := current[name]; !found {
+			rows = append(rows, compositionRow{name: name, previous: size, removed: true})
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].removed != rows[j].removed {
+			return !rows[i].removed
+		}
+		if rows[i].size != rows[j].size {
+			return rows[i].size > rows[j].size
+		}
+		if rows[i].previous != rows[j].previous {
+			return rows[i].previous > rows[j].previous
+		}
+		return rows[i].name < rows[j].name
+	})
+
+	return rows
+}
+
+func (m compositionModel) delta(row compositionRow) string {
+	switch {
+	case m.previous == nil:
+		return "-"
+	case row.added:
+		return "new"
+	case row.removed:
+		return fmt.Sprintf("removed (-%s)", utils.FormatFileSize(row.previous))
+	case row.size > row.previous:
+		return "+" + utils.FormatFileSize(row.size-row.previous)
+	case row.size < row.previous:
+		return "-" + utils.FormatFileSize(row.previous-row.size)
+	}
+
+	return "="
+}
+
+func (m compositionModel) createTable() table.Model {
+	height := m.height - lipgloss.Height(styles.SimpleHeader(m.title(), m.comparedWith())) - lipgloss.Height(m.help.View())
+	nameWidth := max(30, m.width-50)
+
+	columns := []table.Column{
+		{Title: "#", Width: 4},
+		{Title: utils.Ternary(m.pkg == "", "Package", "Module"), Width: nameWidth},
+		{Title: "Size", Width: 10},
+		{Title: "Share", Width: 7},
+		{Title: "Change", Width: 20},
+	}
+
+	var total int64
+	for _, row := range m.rows {
+		total += row.size
+	}
+
+	var rows []table.Row
+
+	for idx, row := range m.rows {
+		share := "-"
+		if total > 0 && !row.removed {
+			share = fmt.Sprintf("%.1f%%", float64(row.size)*100/float64(total))
+		}
+
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", idx+1),
+			row.name,
+			utils.Ternary(row.removed, "-", utils.FormatFileSize(row.size)),
+			share,
+			m.delta(row),
+		})
+	}
+
+	newTable := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(height-4),
+		table.WithWidth(m.width-2),
+	)
+
+	newTable.SetStyles(table.Styles{
+		Header:   tableStyles.Header,
+		Selected: tableStyles.Selected,
+		Cell:     tableStyles.Cell,
+	})
+
+	return newTable
+}
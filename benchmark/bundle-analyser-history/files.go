@@ -0,0 +1,195 @@
+package bundle_analyser_history
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/internal/keymap"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/help"
+	"github.com/ionut-t/gonx/ui/input"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/utils"
+	"strings"
+)
+
+const filesTitle = "📄 Bundle Files"
+
+// closeFilesMsg is sent when the user leaves the files of a benchmark.
+type closeFilesMsg struct{}
+
+// filesModel lists the files of the build output of a benchmark, the largest first.
+type filesModel struct {
+	benchmark data.BundleBenchmark
+	table     table.Model
+	filter    input.Model
+	help      help.Model
+
+	width, height int
+}
+
+func newFilesModel(benchmark data.BundleBenchmark, width, height int) filesModel {
+	helpMenu := help.New(width, height)
+	helpMenu.SetKeyMap(keymap.Model{
+		Up:     keymap.Up,
+		Down:   keymap.Down,
+		Search: keymap.Search,
+		Back:   keymap.Back,
+		Quit:   keymap.Quit,
+		Help:   keymap.Help,
+	})
+
+	m := filesModel{
+		benchmark: benchmark,
+		filter: input.New(input.Options{
+			Width:       60,
+			Placeholder: "Filter by file name or category",
+			Mode:        input.Text,
+			HideHelp:    true,
+		}),
+		help:   helpMenu,
+		width:  width,
+		height: height,
+	}
+
+	m.table = m.createTable()
+
+	return m
+}
+
+func (m filesModel) Update(msg tea.Msg) (filesModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.table = m.createTable()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.help.Keys.Back):
+			if m.filter.Focused() {
+				m.filter.Blur()
+				m.help.Searching = false
+				return m, nil
+			}
+
+			return m, messages.Dispatch(closeFilesMsg{})
+
+		case key.Matches(msg, m.help.Keys.Search):
+			if !m.filter.Focused() && !m.help.FullViewOpened() {
+				m.filter.Focus()
+				m.help.Searching = true
+				return m, nil
+			}
+		}
+	}
+
+	if m.filter.Focused() {
+		filterModel, _ := m.filter.Update(msg)
+		m.filter = filterModel.(input.Model)
+		m.table = m.createTable()
+		return m, nil
+	}
+
+	m.table, cmd = m.table.Update(msg)
+
+	helpMenu, helpCmd := m.help.Update(msg)
+	m.help = helpMenu.(help.Model)
+
+	return m, tea.Batch(cmd, helpCmd)
+}
+
+func (m filesModel) View() string {
+	header := styles.SimpleHeader(m.filter.View(), m.title())
+
+	if len(m.benchmark.Files) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Top,
+			header,
+			lipgloss.NewStyle().Padding(1, 2).Render(
+				styles.DimText.Render("No files were recorded with this benchmark. Run the bundle analyser again to record them."),
+			),
+			m.help.View(),
+		)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		header,
+		tableStyles.Base.Render(m.table.View()),
+		m.help.View(),
+	)
+}
+
+func (m filesModel) title() string {
+	return fmt.Sprintf("%s of %s", filesTitle, m.benchmark.AppName)
+}
+
+// filteredFiles returns the files whose name, normalized name or category contains the filter.
+func (m filesModel) filteredFiles() []data.BundleFile {
+	value := m.filter.Value()
+	if value == "" {
+		return m.benchmark.Files
+	}
+
+	return utils.Filter(m.benchmark.Files, func(file data.BundleFile) bool {
+		return strings.Contains(file.Name, value) ||
+			strings.Contains(file.NormalizedName, value) ||
+			strings.Contains(string(file.Category), value)
+	})
+}
+
+func (m filesModel) createTable() table.Model {
+	height := m.height - lipgloss.Height(styles.SimpleHeader(m.filter.View(), m.title())) - lipgloss.Height(m.help.View())
+	colWidth := max(20, (m.width-64)/2)
+
+	columns := []table.Column{
+		{Title: "#", Width: 4},
+		{Title: "Size", Width: 10},
+		{Title: "Gzip", Width: 10},
+		{Title: "Brotli", Width: 10},
+		{Title: "Category", Width: 10},
+		{Title: "File", Width: colWidth},
+		{Title: "Normalized", Width: colWidth},
+	}
+
+	var rows []table.Row
+
+	for idx, file := range m.filteredFiles() {
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", idx+1),
+			utils.FormatFileSize(file.Size),
+			compressedSize(file.Gzip),
+			compressedSize(file.Brotli),
+			string(file.Category),
+			file.Name,
+			file.NormalizedName,
+		})
+	}
+
+	newTable := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(height-4),
+		table.WithWidth(m.width-2),
+	)
+
+	newTable.SetStyles(table.Styles{
+		Header:   tableStyles.Header,
+		Selected: tableStyles.Selected,
+		Cell:     tableStyles.Cell,
+	})
+
+	return newTable
+}
+
+// compressedSize is blank for the files that aren't compressed, e.g. the images.
+func compressedSize(size int64) string {
+	return utils.Ternary(size > 0, utils.FormatFileSize(size), "-")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ionut-t/gonx/internal/messages"
 	"github.com/ionut-t/gonx/ui/help"
 	"github.com/ionut-t/gonx/ui/input"
+	"github.com/ionut-t/gonx/ui/logs"
 	"github.com/ionut-t/gonx/ui/styles"
 	"github.com/ionut-t/gonx/ui/viewport"
 	"os"
@@ -35,7 +36,18 @@ type Model struct {
 	viewport viewport.Model
 	table    tableModel
 	search   input.Model
-	error    error
+	logs     logs.Model
+	// logsOpened is set while the log viewer covers the history
+	logsOpened bool
+	files      filesModel
+	// filesOpened is set while the files of a benchmark cover the history
+	filesOpened bool
+	composition compositionModel
+	// compositionOpened is set while the composition of a benchmark covers the history
+	compositionOpened bool
+	error             error
+	// compression is the encoding the sizes are shown in, kept across the views
+	compression data.Compression
 
 	help help.Model
 
@@ -53,27 +65,33 @@ func New(width, height int) Model {
 
 	if err != nil {
 		helpMenu.SetKeyMap(keymap.Model{
-			BuildAnalyserHistory: keymap.BuildAnalyserHistory,
-			LintAnalyserHistory:  keymap.LintAnalyserHistory,
-			TestsAnalyserHistory: keymap.TestsAnalyserHistory,
-			Back:                 keymap.Back,
-			Quit:                 keymap.Quit,
-			Help:                 keymap.Help,
+			BuildAnalyserHistory:  keymap.BuildAnalyserHistory,
+			LintAnalyserHistory:   keymap.LintAnalyserHistory,
+			TestsAnalyserHistory:  keymap.TestsAnalyserHistory,
+			TargetAnalyserHistory: keymap.TargetAnalyserHistory,
+			Back:                  keymap.Back,
+			Quit:                  keymap.Quit,
+			Help:                  keymap.Help,
 		})
 	} else {
 		helpMenu.CombineWithHistoryKeys(keymap.Model{
-			BuildAnalyserHistory: keymap.BuildAnalyserHistory,
-			LintAnalyserHistory:  keymap.LintAnalyserHistory,
-			TestsAnalyserHistory: keymap.TestsAnalyserHistory,
+			BuildAnalyserHistory:  keymap.BuildAnalyserHistory,
+			LintAnalyserHistory:   keymap.LintAnalyserHistory,
+			TestsAnalyserHistory:  keymap.TestsAnalyserHistory,
+			TargetAnalyserHistory: keymap.TargetAnalyserHistory,
+			Files:                 keymap.Files,
+			Composition:           keymap.Composition,
+			Compression:           keymap.Compression,
 		})
 	}
 
 	model := Model{
-		view:    listView,
-		metrics: metrics,
-		error:   err,
-		width:   width,
-		height:  height,
+		view:        listView,
+		metrics:     metrics,
+		compression: data.CompressionNone,
+		error:       err,
+		width:       width,
+		height:      height,
 		search: input.New(input.Options{
 			Width:       60,
 			Placeholder: "Search by app name or description",
@@ -115,11 +133,23 @@ func (m Model) View() string {
 		return fmt.Sprintf("Error reading metrics: %s", m.error)
 	}
 
+	if m.logsOpened {
+		return m.logs.View()
+	}
+
+	if m.filesOpened {
+		return m.files.View()
+	}
+
+	if m.compositionOpened {
+		return m.composition.View()
+	}
+
 	switch m.view {
 	case listView, jsonView:
 		return lipgloss.JoinVertical(
 			lipgloss.Top,
-			styles.Header(m.search.View(), title),
+			styles.Header(m.search.View(), m.title()),
 			m.viewport.View(),
 			m.help.View(),
 		)
@@ -127,7 +157,7 @@ func (m Model) View() string {
 	case tableView:
 		return lipgloss.JoinVertical(
 			lipgloss.Top,
-			styles.SimpleHeader(m.search.View(), title),
+			styles.SimpleHeader(m.search.View(), m.title()),
 			m.table.View(),
 			m.help.View(),
 		)
@@ -142,6 +172,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds []tea.Cmd
 	)
 
+	if m.logsOpened {
+		if _, ok := msg.(logs.CloseMsg); ok {
+			m.logsOpened = false
+			return m, nil
+		}
+
+		m.logs, cmd = m.logs.Update(msg)
+		return m, cmd
+	}
+
+	if m.filesOpened {
+		if _, ok := msg.(closeFilesMsg); ok {
+			m.filesOpened = false
+			return m, nil
+		}
+
+		m.files, cmd = m.files.Update(msg)
+		return m, cmd
+	}
+
+	if m.compositionOpened {
+		if _, ok := msg.(closeCompositionMsg); ok {
+			m.compositionOpened = false
+			return m, nil
+		}
+
+		m.composition, cmd = m.composition.Update(msg)
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -167,7 +227,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.help.Keys.TableView):
 			if !m.search.Focused() {
 				m.view = tableView
-				m.table = createTable(m.getFilteredMetrics(), m.width, m.height-lipgloss.Height(styles.SimpleHeader(m.search.View(), title))-lipgloss.Height(m.help.View()))
+				m.table = m.createTable()
 				m.viewport.SetContent(m.table.View())
 			}
 
@@ -177,6 +237,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewport.SetContent(getJsonContent(m))
 			}
 
+		case key.Matches(msg, m.help.Keys.Logs):
+			if !m.search.Focused() {
+				m.logs = logs.New(logEntries(m.getLogsMetrics()), m.width, m.height)
+				m.logsOpened = true
+				return m, nil
+			}
+
+		case key.Matches(msg, m.help.Keys.Compression):
+			if !m.search.Focused() {
+				m.compression = m.compression.Next()
+				m.refreshContent()
+				return m, nil
+			}
+
+		case key.Matches(msg, m.help.Keys.Files):
+			if !m.search.Focused() {
+				if benchmark, ok := m.getFilesMetric(); ok {
+					m.files = newFilesModel(benchmark, m.width, m.height)
+					m.filesOpened = true
+				}
+				return m, nil
+			}
+
+		case key.Matches(msg, m.help.Keys.Composition):
+			if !m.search.Focused() {
+				if benchmark, ok := m.getFilesMetric(); ok {
+					m.composition = newCompositionModel(benchmark, m.getPreviousMetric(benchmark), m.width, m.height)
+					m.compositionOpened = true
+				}
+				return m, nil
+			}
+
 		case key.Matches(msg, m.help.Keys.Search):
 			if !m.search.Focused() && !m.help.FullViewOpened() {
 				m.search.Focus()
@@ -189,15 +281,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.search.Focused() {
 		searchModel, _ := m.search.Update(msg)
 		m.search = searchModel.(input.Model)
-
-		switch m.view {
-		case listView:
-			m.viewport.SetContent(getListContent(m))
-		case tableView:
-			m.table = createTable(m.getFilteredMetrics(), m.width, m.height-lipgloss.Height(styles.SimpleHeader(m.search.View(), title))-lipgloss.Height(m.help.View()))
-		case jsonView:
-			m.viewport.SetContent(getJsonContent(m))
-		}
+		m.refreshContent()
 
 		return m, nil
 	}
@@ -220,6 +304,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// refreshContent renders the current view again, once the search or the encoding of the sizes changed.
+func (m *Model) refreshContent() {
+	switch m.view {
+	case listView:
+		m.viewport.SetContent(getListContent(*m))
+	case tableView:
+		cursor := m.table.table.Cursor()
+		m.table = m.createTable()
+		m.table.table.SetCursor(cursor)
+	case jsonView:
+		m.viewport.SetContent(getJsonContent(*m))
+	}
+}
+
+// title names the encoding of the sizes when they aren't the raw sizes.
+func (m Model) title() string {
+	if m.compression == data.CompressionNone {
+		return title
+	}
+
+	return fmt.Sprintf("%s (%s)", title, m.compression)
+}
+
+func (m Model) createTable() tableModel {
+	return createTable(m.getFilteredMetrics(), m.compression, m.width, m.height-lipgloss.Height(styles.SimpleHeader(m.search.View(), m.title()))-lipgloss.Height(m.help.View()))
+}
+
 func (m Model) getFilteredMetrics() []data.BundleBenchmark {
 	if m.search.Value() == "" {
 		return m.metrics
@@ -227,7 +338,10 @@ func (m Model) getFilteredMetrics() []data.BundleBenchmark {
 
 	filtered := make([]data.BundleBenchmark, 0)
 	for _, metric := range m.metrics {
-		if strings.Contains(metric.AppName, m.search.Value()) || strings.Contains(metric.Description, m.search.Value()) {
+		if strings.Contains(metric.AppName, m.search.Value()) ||
+			strings.Contains(metric.Description, m.search.Value()) ||
+			strings.Contains(metric.TargetOptions.String(), m.search.Value()) ||
+			strings.Contains(metric.SuiteRun.String(), m.search.Value()) {
 			filtered = append(filtered, metric)
 		}
 	}
@@ -235,6 +349,59 @@ func (m Model) getFilteredMetrics() []data.BundleBenchmark {
 	return filtered
 }
 
+// getLogsMetrics returns the benchmark selected in the table view, or all the filtered benchmarks otherwise.
+func (m Model) getLogsMetrics() []data.BundleBenchmark {
+	metrics := m.getFilteredMetrics()
+
+	if m.view == tableView {
+		if cursor := m.table.table.Cursor(); cursor >= 0 && cursor < len(metrics) {
+			return metrics[cursor : cursor+1]
+		}
+	}
+
+	return metrics
+}
+
+// getFilesMetric returns the benchmark selected in the table view, or the latest filtered benchmark otherwise.
+// The files and the composition are shown for that benchmark.
+func (m Model) getFilesMetric() (data.BundleBenchmark, bool) {
+	metrics := m.getFilteredMetrics()
+
+	if len(metrics) == 0 {
+		return data.BundleBenchmark{}, false
+	}
+
+	if m.view == tableView {
+		if cursor := m.table.table.Cursor(); cursor >= 0 && cursor < len(metrics) {
+			return metrics[cursor], true
+		}
+	}
+
+	return metrics[0], true
+}
+
+// getPreviousMetric returns the benchmark of the same app recorded before the given one with a composition,
+// whether or not it matches the search, nil when there is none.
+func (m Model) getPreviousMetric(benchmark data.BundleBenchmark) *data.BundleBenchmark {
+	older := false
+
+	// the metrics are stored the latest first
+	for i, metric := range m.metrics {
+		if metric.ID == benchmark.ID {
+			older = true
+			continue
+		}
+
+		if older && metric.AppName == benchmark.AppName && len(metric.Composition) > 0 {
+			return &m.metrics[i]
+		}
+	}
+
+	return nil
+}
+
+// Searching reports whether the key presses are captured by the search input, the log viewer,
+// or the files or the composition of a benchmark.
 func (m Model) Searching() bool {
-	return m.search.Focused()
+	return m.search.Focused() || m.logsOpened || m.filesOpened || m.compositionOpened
 }
@@ -13,9 +13,30 @@ import (
 	"os"
 )
 
+// jsonMetric shows a benchmark with the stats in the encoding picked in the history,
+// null when the benchmark was recorded before the compressed sizes were measured.
+type jsonMetric struct {
+	data.BundleBenchmark
+	Compression data.Compression `json:"compression"`
+	Stats       *data.BuildStats `json:"stats"`
+	GzipStats   *data.BuildStats `json:"gzipStats,omitempty"`
+	BrotliStats *data.BuildStats `json:"brotliStats,omitempty"`
+}
+
 func getJsonContent(model Model) string {
+	metrics := model.getFilteredMetrics()
+
+	shown := make([]jsonMetric, len(metrics))
+	for i, metric := range metrics {
+		shown[i] = jsonMetric{BundleBenchmark: metric, Compression: model.compression}
+
+		if stats, ok := metric.StatsOf(model.compression); ok {
+			shown[i].Stats = &stats
+		}
+	}
+
 	// Convert metrics to pretty JSON
-	jsonData, err := json.MarshalIndent(model.getFilteredMetrics(), "", "  ")
+	jsonData, err := json.MarshalIndent(shown, "", "  ")
 	if err != nil {
 		return fmt.Sprintf("Error marshaling JSON: %v", err)
 	}
@@ -3,6 +3,8 @@ package bundle_analyser_history
 import (
 	"fmt"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ionut-t/gonx/benchmark/budget"
+	data "github.com/ionut-t/gonx/benchmark/data"
 	"github.com/ionut-t/gonx/ui/styles"
 	"github.com/ionut-t/gonx/utils"
 	"strings"
@@ -21,18 +23,44 @@ func getListContent(model Model) string {
 			styles.NormalText.Render(fmt.Sprintf("%sRecorded on %s at %s", styles.IconStyle("🗓️"), bm.CreatedAt.Format("02/01/2006"), bm.CreatedAt.Format("15:04:05"))),
 			styles.NormalText.Render(fmt.Sprintf("%sDescription: %s", styles.IconStyle("📝"), utils.Ternary(bm.Description == "", "-", bm.Description))),
 			styles.NormalText.Render(fmt.Sprintf("%sApp: %s", styles.IconStyle("💻"), bm.AppName)),
+			styles.NormalText.Render(fmt.Sprintf("%sOptions: %s", styles.IconStyle("🔩"), utils.Ternary(bm.TargetOptions.String() == "", "-", bm.TargetOptions.String()))),
 			styles.Success.Render(fmt.Sprintf("%sBuild time: %.2fs", styles.IconStyle("🕒"), bm.Duration)),
-			styles.Success.Render(fmt.Sprintf("%sMain bundle: %s", styles.IconStyle("🎯"), utils.FormatFileSize(bm.Stats.Initial.Main))),
-			styles.Success.Render(fmt.Sprintf("%sRuntime bundle: %s", styles.IconStyle("⚙️"), utils.FormatFileSize(bm.Stats.Initial.Runtime))),
-			styles.Success.Render(fmt.Sprintf("%sPolyfills bundle: %s", styles.IconStyle("🔧"), utils.FormatFileSize(bm.Stats.Initial.Polyfills))),
-			styles.Warning.Render(fmt.Sprintf("%sInitial total: %s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Initial.Total))),
-			styles.Accent.Render(fmt.Sprintf("%sLazy chunks total: %s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Lazy))),
-			styles.Info.Render(fmt.Sprintf("%sBundle total: %s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Total))),
-			styles.Info.Render(fmt.Sprintf("%sStyles total: %s", styles.IconStyle("🎨"), utils.FormatFileSize(bm.Stats.Styles))),
-			styles.Info.Render(fmt.Sprintf("%sAssets total: %s", styles.IconStyle("📂"), utils.FormatFileSize(bm.Stats.Assets))),
-			styles.Info.Render(fmt.Sprintf("%sOverall total: %s", styles.IconStyle("📊"), utils.FormatFileSize(bm.Stats.OverallTotal))),
 		)
 
+		if stats, ok := bm.StatsOf(model.compression); ok {
+			content = lipgloss.JoinVertical(
+				lipgloss.Left,
+				content,
+				styles.Success.Render(fmt.Sprintf("%sMain bundle: %s", styles.IconStyle("🎯"), utils.FormatFileSize(stats.Initial.Main))),
+				styles.Success.Render(fmt.Sprintf("%sRuntime bundle: %s", styles.IconStyle("⚙️"), utils.FormatFileSize(stats.Initial.Runtime))),
+				styles.Success.Render(fmt.Sprintf("%sPolyfills bundle: %s", styles.IconStyle("🔧"), utils.FormatFileSize(stats.Initial.Polyfills))),
+				styles.Warning.Render(fmt.Sprintf("%sInitial total: %s", styles.IconStyle("📦"), utils.FormatFileSize(stats.Initial.Total))),
+				styles.Accent.Render(fmt.Sprintf("%sLazy chunks total: %s", styles.IconStyle("📦"), utils.FormatFileSize(stats.Lazy))),
+				styles.Info.Render(fmt.Sprintf("%sBundle total: %s", styles.IconStyle("📦"), utils.FormatFileSize(stats.Total))),
+				styles.Info.Render(fmt.Sprintf("%sStyles total: %s", styles.IconStyle("🎨"), utils.FormatFileSize(stats.Styles))),
+				styles.Info.Render(fmt.Sprintf("%sAssets total: %s", styles.IconStyle("📂"), utils.FormatFileSize(stats.Assets))),
+				styles.Info.Render(fmt.Sprintf("%sOverall total: %s", styles.IconStyle("📊"), utils.FormatFileSize(stats.OverallTotal))),
+			)
+		} else {
+			content += "\n" + styles.DimText.Render(fmt.Sprintf("%sThe %s sizes weren't recorded with this benchmark.", styles.IconStyle("📦"), model.compression))
+		}
+
+		if bm.Classifier != "" {
+			content += "\n" + styles.NormalText.Render(fmt.Sprintf("%sInitial files told by: %s", styles.IconStyle("🔍"), bm.Classifier))
+		}
+
+		if bm.Suite != "" {
+			content += "\n" + styles.Info.Render(fmt.Sprintf("%sSuite: %s", styles.IconStyle("🧪"), bm.SuiteRun))
+		}
+
+		if len(bm.Budgets) > 0 {
+			content += "\n" + renderBudgets(bm.Budgets)
+		}
+
+		if len(bm.BudgetChecks) > 0 {
+			content += "\n" + budget.Render(bm.BudgetChecks)
+		}
+
 		if i < len(metrics)-1 {
 			content += "\n\n" + border + "\n"
 		}
@@ -47,3 +75,23 @@ func getListContent(model Model) string {
 			contents...,
 		))
 }
+
+// renderBudgets lists the checks of the Angular budgets, styled by their status.
+func renderBudgets(budgets []data.BudgetResult) string {
+	lines := []string{styles.NormalText.Render(fmt.Sprintf("%sBudgets:", styles.IconStyle("💰")))}
+
+	for _, budget := range budgets {
+		switch budget.Status {
+		case data.BudgetError:
+			lines = append(lines, styles.Error.Render("   ✘ "+budget.String()))
+		case data.BudgetWarning:
+			lines = append(lines, styles.Warning.Render("   ▲ "+budget.String()))
+		case data.BudgetSkipped:
+			lines = append(lines, styles.DimText.Render("   - "+budget.String()))
+		default:
+			lines = append(lines, styles.Success.Render("   ✓ "+budget.String()))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
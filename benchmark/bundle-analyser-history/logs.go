@@ -0,0 +1,25 @@
+package bundle_analyser_history
+
+import (
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/ui/logs"
+)
+
+// logEntries returns the build logs of the given benchmarks.
+func logEntries(metrics []data.BundleBenchmark) []logs.Entry {
+	var entries []logs.Entry
+
+	for _, bm := range metrics {
+		if bm.Log == "" {
+			continue
+		}
+
+		entries = append(entries, logs.Entry{
+			Title: fmt.Sprintf("%s · %s", bm.AppName, bm.CreatedAt.Format("02/01/06 15:04")),
+			Path:  bm.Log,
+		})
+	}
+
+	return entries
+}
@@ -43,9 +43,9 @@ func (m tableModel) View() string {
 	return tableStyles.Base.Render(m.table.View())
 }
 
-func createTable(metrics []data.BundleBenchmark, width, height int) tableModel {
+func createTable(metrics []data.BundleBenchmark, compression data.Compression, width, height int) tableModel {
 	lipgloss.NewStyle().Padding(0, 1)
-	colWidth := (width - 55) / 8
+	colWidth := (width - 77) / 8
 
 	columns := []table.Column{
 		{Title: "#", Width: 3},
@@ -59,23 +59,34 @@ func createTable(metrics []data.BundleBenchmark, width, height int) tableModel {
 		{Title: "Lazy", Width: colWidth},
 		{Title: "Styles", Width: colWidth},
 		{Title: "Assets", Width: colWidth},
+		{Title: "Budgets", Width: 10},
+		{Title: "Perf budget", Width: 12},
 	}
 
 	var rows []table.Row
 
 	for idx, bm := range metrics {
+		stats, ok := bm.StatsOf(compression)
+
+		// the benchmarks recorded before the compressed sizes were measured have none to show
+		size := func(bytes int64) string {
+			return utils.Ternary(ok, utils.FormatFileSizeInMB(bytes), "-")
+		}
+
 		rows = append(rows, table.Row{
 			fmt.Sprintf("%d", idx+1),
 			bm.AppName,
 			bm.CreatedAt.Format("02/01/06 15:04"),
 			fmt.Sprintf("%.2fs", bm.Duration),
-			utils.FormatFileSizeInMB(bm.Stats.Initial.Main),
-			utils.FormatFileSizeInMB(bm.Stats.Initial.Runtime),
-			utils.FormatFileSizeInMB(bm.Stats.Initial.Polyfills),
-			utils.FormatFileSizeInMB(bm.Stats.Initial.Total),
-			utils.FormatFileSizeInMB(bm.Stats.Lazy),
-			utils.FormatFileSizeInMB(bm.Stats.Styles),
-			utils.FormatFileSizeInMB(bm.Stats.Assets),
+			size(stats.Initial.Main),
+			size(stats.Initial.Runtime),
+			size(stats.Initial.Polyfills),
+			size(stats.Initial.Total),
+			size(stats.Lazy),
+			size(stats.Styles),
+			size(stats.Assets),
+			utils.Ternary(len(bm.Budgets) == 0, "-", string(bm.BudgetStatus())),
+			bm.BudgetChecks.Label(),
 		})
 	}
 
@@ -0,0 +1,241 @@
+package bundle_analyser
+
+import (
+	"fmt"
+	"github.com/ionut-t/gonx/benchmark/budget"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// componentStyleBudget matches the messages of the Angular builders about a component style over its budget, e.g.
+// "▲ [WARNING] src/app/app.component.scss exceeded maximum budget. Budget 2.00 kB was not met by 184 bytes".
+var componentStyleBudget = regexp.MustCompile(`(\S+\.(?:css|scss|sass|less)) exceeded (?:maximum|minimum) budget`)
+
+// errorMessage matches the messages of the builders reported as errors, "✘ [ERROR]" with esbuild and "Error:" with webpack.
+var errorMessage = regexp.MustCompile(`\[ERROR\]|(?i)^\s*error:`)
+
+// checkBudgets checks the output against the budgets of the build target of the app, in the configuration of the benchmark.
+func (b *BundleBenchmark) checkBudgets(app workspace.Application) []data.BudgetResult {
+	build, ok := app.Targets["build"]
+	if !ok {
+		return nil
+	}
+
+	budgets := build.Budgets(b.Configuration)
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	results := make([]data.BudgetResult, 0, len(budgets))
+	for _, budget := range budgets {
+		results = append(results, b.checkBudget(budget))
+	}
+
+	return results
+}
+
+func (b *BundleBenchmark) checkBudget(budget workspace.Budget) data.BudgetResult {
+	result := data.BudgetResult{Type: budget.Type, Name: budget.Name}
+
+	if err := setThresholds(&result, budget); err != nil {
+		result.Status, result.Message = data.BudgetSkipped, err.Error()
+		return result
+	}
+
+	switch budget.Type {
+	case "initial":
+		// the global stylesheets are loaded with the page, the lazy ones being too rare to tell apart
+		result.Size = b.Stats.Initial.Total + b.Stats.Styles
+	case "allScript":
+		result.Size = b.Stats.Total
+	case "all":
+		result.Size = b.Stats.OverallTotal
+	case "anyScript":
+		checkEachFile(&result, b.Files, isScript)
+		return result
+	case "any":
+		checkEachFile(&result, b.Files, func(file data.BundleFile) bool {
+			return isScript(file) || file.Category == data.FileStyles
+		})
+		return result
+	case "bundle":
+		found := false
+		for _, file := range b.Files {
+			if isBundle(file, budget.Name) && (isScript(file) || file.Category == data.FileStyles) {
+				result.Size += file.Size
+				found = true
+			}
+		}
+
+		if !found {
+			result.Status, result.Message = data.BudgetSkipped, fmt.Sprintf("No bundle named %s in the output", budget.Name)
+			return result
+		}
+	case "anyComponentStyle":
+		b.checkComponentStyles(&result)
+		return result
+	default:
+		result.Status, result.Message = data.BudgetSkipped, fmt.Sprintf("Unknown budget type %s", budget.Type)
+		return result
+	}
+
+	result.Status = check(result, result.Size)
+
+	return result
+}
+
+// checkComponentStyles goes by the build output, the component styles being inlined in the scripts: the Angular
+// builders report the ones over their budget, and fail the build on an error budget.
+func (b *BundleBenchmark) checkComponentStyles(result *data.BudgetResult) {
+	content, err := os.ReadFile(b.Log)
+	if err != nil {
+		result.Status, result.Message = data.BudgetSkipped, "The component styles are checked by the build, whose output wasn't captured"
+		return
+	}
+
+	result.Status = data.BudgetOK
+
+	for _, line := range strings.Split(string(content), "\n") {
+		match := componentStyleBudget.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		status := utils.Ternary(errorMessage.MatchString(line), data.BudgetError, data.BudgetWarning)
+		if result.File == "" || status == data.BudgetError && result.Status != data.BudgetError {
+			result.File, result.Status = match[1], status
+			result.Message = strings.TrimSpace(line[strings.Index(line, match[1]):])
+		}
+	}
+}
+
+// checkEachFile checks every file selected against the budget and keeps the worst, the largest one when they pass.
+func checkEachFile(result *data.BudgetResult, files []data.BundleFile, selected func(data.BundleFile) bool) {
+	result.Status = data.BudgetOK
+
+	for _, file := range files {
+		if !selected(file) {
+			continue
+		}
+
+		status := check(*result, file.Size)
+		if result.File == "" || severity(status) > severity(result.Status) ||
+			severity(status) == severity(result.Status) && file.Size > result.Size {
+			result.File, result.Size, result.Status = file.Name, file.Size, status
+		}
+	}
+}
+
+// check returns the status of a size against the thresholds of the budget.
+func check(budget data.BudgetResult, size int64) data.BudgetStatus {
+	switch {
+	case budget.MaximumError > 0 && size > budget.MaximumError, budget.MinimumError > 0 && size < budget.MinimumError:
+		return data.BudgetError
+	case budget.MaximumWarning > 0 && size > budget.MaximumWarning, budget.MinimumWarning > 0 && size < budget.MinimumWarning:
+		return data.BudgetWarning
+	}
+
+	return data.BudgetOK
+}
+
+func severity(status data.BudgetStatus) int {
+	switch status {
+	case data.BudgetError:
+		return 2
+	case data.BudgetWarning:
+		return 1
+	}
+
+	return 0
+}
+
+// setThresholds reads the sizes of the budget. The warning and error sizes are relative to the baseline,
+// both as a maximum and a minimum, and the lowest maximum and the highest minimum win.
+func setThresholds(result *data.BudgetResult, budget workspace.Budget) error {
+	thresholds := []struct {
+		value    string
+		factor   int
+		field    *int64
+		maximum  bool
+		relative bool
+	}{
+		{budget.MaximumWarning, 1, &result.MaximumWarning, true, false},
+		{budget.MaximumError, 1, &result.MaximumError, true, false},
+		{budget.MinimumWarning, -1, &result.MinimumWarning, false, false},
+		{budget.MinimumError, -1, &result.MinimumError, false, false},
+		{budget.Warning, 1, &result.MaximumWarning, true, true},
+		{budget.Warning, -1, &result.MinimumWarning, false, true},
+		{budget.Error, 1, &result.MaximumError, true, true},
+		{budget.Error, -1, &result.MinimumError, false, true},
+	}
+
+	for _, threshold := range thresholds {
+		if threshold.value == "" {
+			continue
+		}
+
+		if threshold.relative && budget.Baseline == "" {
+			return fmt.Errorf("%s is relative to a baseline, which the budget doesn't set", threshold.value)
+		}
+
+		size, err := calculateBytes(threshold.value, budget.Baseline, threshold.factor)
+		if err != nil {
+			return err
+		}
+
+		if threshold.maximum && (*threshold.field == 0 || size < *threshold.field) ||
+			!threshold.maximum && size > *threshold.field {
+			*threshold.field = size
+		}
+	}
+
+	return nil
+}
+
+// calculateBytes reads a size of a budget the way Angular does: the units are multiples of 1024, and a size is
+// relative to the baseline when the budget sets one, added to it or taken from it depending on the factor.
+func calculateBytes(value, baseline string, factor int) (int64, error) {
+	var baselineBytes float64
+	if baseline != "" {
+		bytes, err := budget.ParseSize(baseline, 0)
+		if err != nil {
+			return 0, err
+		}
+		baselineBytes = bytes
+	}
+
+	size, err := budget.ParseSize(value, baselineBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	if baselineBytes == 0 {
+		return int64(size), nil
+	}
+
+	return int64(baselineBytes + size*float64(factor)), nil
+}
+
+func isScript(file data.BundleFile) bool {
+	switch file.Category {
+	case data.FileMain, data.FileRuntime, data.FilePolyfills, data.FileLazy:
+		return true
+	}
+
+	return false
+}
+
+// isBundle tells whether the file is of the bundle Angular names, e.g. main for main-5DZ7QKTA.js or main.a1b2c3d4.js,
+// the hash being matched as the suffix of the name when it's too short to be stripped from the normalized name.
+func isBundle(file data.BundleFile, name string) bool {
+	base := path.Base(file.NormalizedName)
+	base = strings.TrimSuffix(base, path.Ext(base))
+
+	return base == name || strings.HasPrefix(base, name+"-") && !strings.Contains(base[len(name)+1:], "-") ||
+		strings.HasPrefix(base, name+".") && !strings.Contains(base[len(name)+1:], ".")
+}
@@ -0,0 +1,43 @@
+package bundle_analyser
+
+import "testing"
+
+func TestCalculateBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		baseline string
+		factor   int
+		want     int64
+		err      bool
+	}{
+		{name: "bytes", value: "100", factor: 1, want: 100},
+		{name: "bytes with a unit", value: "100b", factor: 1, want: 100},
+		{name: "kilobytes", value: "500kb", factor: 1, want: 500 * 1024},
+		{name: "megabytes with a space", value: "1.5 MB", factor: 1, want: 1572864},
+		{name: "gigabytes", value: "1gb", factor: 1, want: 1024 * 1024 * 1024},
+		{name: "added to the baseline", value: "10kb", baseline: "1mb", factor: 1, want: 1024*1024 + 10*1024},
+		{name: "taken from the baseline", value: "10kb", baseline: "1mb", factor: -1, want: 1024*1024 - 10*1024},
+		{name: "percentage of the baseline", value: "10%", baseline: "1mb", factor: 1, want: 1153433},
+		{name: "percentage below the baseline", value: "10%", baseline: "1mb", factor: -1, want: 943718},
+		{name: "percentage without a baseline", value: "10%", factor: 1, err: true},
+		{name: "invalid size", value: "large", factor: 1, err: true},
+		{name: "invalid baseline", value: "1kb", baseline: "huge", factor: 1, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := calculateBytes(test.value, test.baseline, test.factor)
+			if test.err {
+				if err == nil {
+					t.Errorf("calculateBytes(%q, %q, %d) = %d, want an error", test.value, test.baseline, test.factor, got)
+				}
+				return
+			}
+
+			if err != nil || got != test.want {
+				t.Errorf("calculateBytes(%q, %q, %d) = %d, %v, want %d", test.value, test.baseline, test.factor, got, err, test.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,23 @@
 package bundle_analyser
 
 import (
+	"context"
 	"fmt"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ionut-t/gonx/benchmark/budget"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	form "github.com/ionut-t/gonx/benchmark/shared-form"
 	"github.com/ionut-t/gonx/internal/keymap"
 	"github.com/ionut-t/gonx/internal/messages"
-	"github.com/ionut-t/gonx/ui/input"
+	"github.com/ionut-t/gonx/ui/logs"
 	"github.com/ionut-t/gonx/ui/styles"
 	"github.com/ionut-t/gonx/ui/suspense"
+	"github.com/ionut-t/gonx/ui/tail"
 	"github.com/ionut-t/gonx/ui/viewport"
 	"github.com/ionut-t/gonx/utils"
 	"github.com/ionut-t/gonx/workspace"
@@ -23,61 +29,81 @@ const resultTitle = "📊 Benchmark results"
 
 const padding = 2
 
+var buildHint = fmt.Sprintf(
+	"Press %s to cancel the benchmark or %s to toggle the output.",
+	keymap.Cancel.Help().Key,
+	keymap.Output.Help().Key,
+)
+
+var logsHint = fmt.Sprintf("Press %s to browse the logs of the builds.", keymap.Logs.Help().Key)
+
 type view int
 
 const (
-	descriptionView view = iota
+	formView view = iota
 	buildView
 	resultsView
+	logsView
 )
 
 type Model struct {
-	view        view
-	apps        []workspace.Application
-	description input.Model
-	viewport    viewport.Model
-	suspense    suspense.Model
-	progress    progress.Model
+	view          view
+	apps          []workspace.Application
+	configuration string
+	form          form.Model
+	logs          logs.Model
+	viewport      viewport.Model
+	suspense      suspense.Model
+	progress      progress.Model
+	output        tail.Model
 
 	width  int
 	height int
 
-	completed      int
-	totalProcesses int
-	results        []BundleBenchmark
+	ctx        context.Context
+	cancel     context.CancelFunc
+	cancelled  bool
+	events     <-chan runner.Event
+	completed  int
+	totalSteps int
+	runLogs    []logs.Entry
+	results    []BundleBenchmark
+	err        error
 }
 
-func New(apps []workspace.Application, width, height int) Model {
+func New(ctx context.Context, apps []workspace.Application, configuration string, width, height int) Model {
 	return Model{
-		apps:        apps,
-		width:       width,
-		height:      height,
-		description: createDescriptionInput(),
+		ctx:           ctx,
+		apps:          apps,
+		configuration: configuration,
+		width:         width,
+		height:        height,
+		form:          form.NewTargetOptions(),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return m.description.Init()
+	return m.form.Init()
 }
 
 func (m Model) View() string {
 	switch m.view {
-	case descriptionView:
-		return lipgloss.NewStyle().Padding(1, 1).Render(m.description.View())
+	case formView:
+		return lipgloss.NewStyle().Padding(1, 1).Render(m.form.View())
 
 	case buildView:
-		return lipgloss.JoinVertical(
-			lipgloss.Left,
-			lipgloss.NewStyle().Padding(1, 1).Render(m.suspense.View()),
-			lipgloss.NewStyle().Padding(0, 1).Render(m.progress.View()),
-		)
+		return m.output.Under(m.statusView())
 
 	case resultsView:
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
 			styles.Header("", resultTitle),
 			m.viewport.View(),
+			lipgloss.NewStyle().Padding(0, 1).Render(styles.DimText.Render(logsHint)),
 		)
+
+	case logsView:
+		return m.logs.View()
 	}
 
 	return ""
@@ -89,63 +115,126 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds []tea.Cmd
 	)
 
+	if m.view == logsView {
+		if _, ok := msg.(logs.CloseMsg); ok {
+			m.view = resultsView
+			return m, nil
+		}
+
+		m.logs, cmd = m.logs.Update(msg)
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.progress.Width = m.width - padding*2
+		m.output.SetSize(m.statusView(), m.width, m.height)
 
-	case input.DoneMsg:
+	case form.FormMsg:
 		m.view = buildView
 		m.completed = 0
 
-		return m, messages.Dispatch(StartMsg{StartTime: time.Now(), Apps: m.apps, Description: string(msg)})
+		return m, messages.Dispatch(StartMsg{
+			StartTime:   time.Now(),
+			Apps:        m.apps,
+			Description: msg.Description,
+			TargetOptions: data.TargetOptions{
+				Configuration: m.configuration,
+				Args:          msg.Args,
+				Env:           msg.Env,
+			},
+		})
 
 	case StartMsg:
 		m.completed = 0
+		m.err = nil
+		// every app is built once, then its bundle size is calculated
+		m.totalSteps = len(msg.Apps) * 2
 		m.results = make([]BundleBenchmark, 0)
 		m.suspense = suspense.New("Starting bundle benchmark", true)
 		m.progress = progress.New(progress.WithDefaultGradient())
 		m.progress.Width = m.width - padding*2
 		m.progress.PercentageStyle = styles.Primary
+		m.output = tail.New(m.statusView(), m.width, m.height)
+		m.cancelled = false
+		m.runLogs = nil
+
+		var ctx context.Context
+		ctx, m.cancel = context.WithCancel(m.ctx)
+		m.events = Run(ctx, msg.Apps, msg.Description, msg.TargetOptions, data.SuiteRun{})
 
 		return m, tea.Batch(
-			startBenchmark(msg.Apps, msg.Description),
+			m.listen(),
 			m.suspense.Spinner.Tick,
 			m.progress.SetPercent(0.0),
 		)
 
-	case TotalProcessesMsg:
-		m.totalProcesses = int(msg)
-
-	case NxCacheResetStartMsg:
+	case runner.ResetStarted:
 		m.suspense.Message = "Resetting the Nx cache and stopping the daemon"
-		m.suspense.Loading = true
-		return m, m.suspense.Spinner.Tick
+		return m, m.listen()
 
-	case BuildStartMsg:
-		m.suspense.Message = fmt.Sprintf("Building %s application", styles.Primary.Bold(true).Render(msg.App))
-		return m, m.progress.IncrPercent(m.getProgressIncrement())
+	case runner.ResetFinished:
+		if msg.Error != nil {
+			m.err = msg.Error
+			m.suspense.Message = msg.Error.Error()
+		}
+		return m, m.listen()
 
-	case CalculateBundleSizeMsg:
-		m.suspense.Message = fmt.Sprintf("Calculating bundle size for %s application", styles.Primary.Bold(true).Render(msg.App))
-		return m, m.progress.IncrPercent(m.getProgressIncrement())
+	case runner.RunStarted:
+		m.suspense.Message = fmt.Sprintf("Building %s application", styles.Primary.Bold(true).Render(msg.Project.GetName()))
+		m.output.Reset(fmt.Sprintf("nx build %s", msg.Project.GetName()))
+		return m, m.listen()
 
-	case WriteStatsMsg:
-		m.suspense.Message = fmt.Sprintf("Writing stats for %s application", styles.Primary.Bold(true).Render(msg.App))
-		return m, m.progress.IncrPercent(m.getProgressIncrement())
+	case runner.Output:
+		m.output.Push(msg.Project.GetName(), msg.Lines...)
+		return m, m.listen()
 
-	case BuildCompleteMsg:
+	case runner.RunFinished:
 		m.completed++
-		m.results = append(m.results, msg.Benchmark)
+		if msg.Error != nil {
+			// the bundle size of a failed build is not calculated
+			m.completed++
+			m.err = utils.Errorf("Build failed for %s with: %v", msg.Project.GetName(), msg.Error)
+			m.suspense.Message = m.err.Error()
+		}
+		if msg.Log != "" {
+			m.runLogs = append(m.runLogs, logs.Entry{
+				Title:  msg.Project.GetName(),
+				Path:   msg.Log,
+				Failed: msg.Status == data.RunFailed,
+			})
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
 
-		return m.handleBenchmarkBuild(nil)
+	case runner.StatsStarted:
+		m.suspense.Message = fmt.Sprintf("Calculating bundle size for %s application", styles.Primary.Bold(true).Render(msg.Project.GetName()))
+		return m, m.listen()
 
-	case BuildFailedMsg:
+	case runner.StatsWritten[BundleBenchmark]:
 		m.completed++
-		m.suspense.Message = msg.Error.Error()
+		if msg.Error != nil {
+			m.err = msg.Error
+			m.suspense.Message = msg.Error.Error()
+		} else {
+			m.err = nil
+			m.results = append(m.results, msg.Benchmark)
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
 
-		return m.handleBenchmarkBuild(msg.Error)
+	case CompleteMsg:
+		m.cancel()
+		err := m.err
+		return m, tea.Sequence(
+			m.progress.SetPercent(1.0),
+			// wait for the progress bar to finish animating
+			tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
+				return DoneMsg{
+					Error: err,
+				}
+			}),
+		)
 
 	case DoneMsg:
 		if msg.Error != nil {
@@ -180,10 +269,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		switch {
+		case key.Matches(msg, keymap.Cancel):
+			if m.view == buildView && m.cancel != nil && !m.cancelled {
+				m.cancel()
+				m.cancelled = true
+				m.suspense.Message = "Cancelling the benchmark..."
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Output):
+			if m.view == buildView {
+				m.output.Toggle()
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Logs):
+			if m.view == resultsView {
+				m.logs = logs.New(m.runLogs, m.width, m.height)
+				m.view = logsView
+				return m, nil
+			}
+
 		case key.Matches(msg, keymap.Back):
 			if m.view != buildView {
 				return m, messages.Dispatch(messages.NavigateToViewMsg(
-					utils.Ternary(m.view == descriptionView, 1, 0)),
+					utils.Ternary(m.view == formView, 1, 0)),
 				)
 			}
 		}
@@ -195,9 +305,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cmds = append(cmds, cmd)
 
 	switch m.view {
-	case descriptionView:
-		inputModel, cmd := m.description.Update(msg)
-		m.description = inputModel.(input.Model)
+	case formView:
+		formModel, cmd := m.form.Update(msg)
+		m.form = formModel.(form.Model)
 		cmds = append(cmds, cmd)
 
 	case buildView:
@@ -215,37 +325,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func createDescriptionInput() input.Model {
-	options := input.Options{
-		Label:       "You can provide an optional description for this benchmark",
-		Placeholder: "Description",
-		Width:       60,
-		Mode:        input.Text,
-	}
-
-	descriptionInput := input.New(options)
-	descriptionInput.Focus()
-	return descriptionInput
+func (m Model) statusView() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Padding(1, 1).Render(m.suspense.View()),
+		lipgloss.NewStyle().Padding(0, 1).Render(m.progress.View()),
+		lipgloss.NewStyle().Padding(1, 1).Render(styles.DimText.Render(buildHint)),
+	)
 }
 
-func (m Model) handleBenchmarkBuild(err error) (Model, tea.Cmd) {
-	if m.completed == len(m.apps) {
-		return m, tea.Sequence(
-			m.progress.SetPercent(1.0),
-			// wait for the progress bar to finish animating
-			tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
-				return DoneMsg{
-					Error: err,
-				}
-			}),
-		)
-	}
-
-	return m, m.progress.IncrPercent(m.getProgressIncrement())
+func (m Model) listen() tea.Cmd {
+	return messages.Listen(m.events, CompleteMsg{})
 }
 
-func (m Model) getProgressIncrement() float64 {
-	return 1.0 / float64(m.totalProcesses)
+func (m Model) progressPercent() float64 {
+	return float64(m.completed) / float64(m.totalSteps)
 }
 
 func renderBenchmarkResults(m *Model) {
@@ -263,6 +357,10 @@ func renderBenchmarkResults(m *Model) {
 		contents = append(contents, content)
 	}
 
+	if m.cancelled {
+		contents = append([]string{styles.Warning.Render(cancelledMessage(len(results))) + "\n"}, contents...)
+	}
+
 	output := lipgloss.NewStyle().
 		Padding(0, 4).
 		Render(lipgloss.JoinVertical(
@@ -282,7 +380,7 @@ func renderBenchmarkResults(m *Model) {
 func renderStats(bm BundleBenchmark, width int) string {
 	border := styles.NormalText.Render(strings.Repeat("─", min(50, width-padding)))
 
-	return lipgloss.JoinVertical(
+	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		border,
 		styles.NormalText.Render(fmt.Sprintf("Stats for %s app:", styles.Primary.Render(bm.AppName))),
@@ -290,20 +388,68 @@ func renderStats(bm BundleBenchmark, width int) string {
 		styles.NormalText.Render(fmt.Sprintf("%sRecorded on %s at %s", styles.IconStyle("🗓️"), bm.CreatedAt.Format("02/01/2006"), bm.CreatedAt.Format("15:04:05"))),
 		styles.NormalText.Render(fmt.Sprintf("%sDescription: %s", styles.IconStyle("📝"), utils.Ternary(bm.Description == "", "-", bm.Description))),
 		styles.NormalText.Render(fmt.Sprintf("%sApp: %s", styles.IconStyle("💻"), bm.AppName)),
+		styles.NormalText.Render(fmt.Sprintf("%sOptions: %s", styles.IconStyle("🔩"), utils.Ternary(bm.TargetOptions.String() == "", "-", bm.TargetOptions.String()))),
+		styles.NormalText.Render(fmt.Sprintf("%sInitial files told by: %s", styles.IconStyle("🔍"), bm.Classifier)),
 		styles.Success.Render(fmt.Sprintf("%sBuild time: %.2fs", styles.IconStyle("🕒"), bm.Duration)),
 		styles.Success.Render(fmt.Sprintf("%sMain bundle: %s", styles.IconStyle("🎯"), utils.FormatFileSize(bm.Stats.Initial.Main))),
 		styles.Success.Render(fmt.Sprintf("%sRuntime bundle: %s", styles.IconStyle("⚙️"), utils.FormatFileSize(bm.Stats.Initial.Runtime))),
 		styles.Success.Render(fmt.Sprintf("%sPolyfills bundle: %s", styles.IconStyle("🔧"), utils.FormatFileSize(bm.Stats.Initial.Polyfills))),
-		styles.Warning.Render(fmt.Sprintf("%sInitial total: %s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Initial.Total))),
+		styles.Warning.Render(fmt.Sprintf("%sInitial total: %s%s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Initial.Total), compressedSizes(bm, func(s data.BuildStats) int64 { return s.Initial.Total }))),
 		styles.Accent.Render(fmt.Sprintf("%sLazy chunks total: %s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Lazy))),
-		styles.Info.Render(fmt.Sprintf("%sBundle total: %s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Total))),
+		styles.Info.Render(fmt.Sprintf("%sBundle total: %s%s", styles.IconStyle("📦"), utils.FormatFileSize(bm.Stats.Total), compressedSizes(bm, func(s data.BuildStats) int64 { return s.Total }))),
 		styles.Info.Render(fmt.Sprintf("%sStyles total: %s", styles.IconStyle("🎨"), utils.FormatFileSize(bm.Stats.Styles))),
 		styles.Info.Render(fmt.Sprintf("%sAssets total: %s", styles.IconStyle("📂"), utils.FormatFileSize(bm.Stats.Assets))),
 		styles.Info.Render(fmt.Sprintf("%sOverall total: %s", styles.IconStyle("📊"), utils.FormatFileSize(bm.Stats.OverallTotal))),
-		border,
 	)
+
+	if len(bm.Budgets) > 0 {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, renderBudgets(bm.Budgets))
+	}
+
+	if len(bm.BudgetChecks) > 0 {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, budget.Render(bm.BudgetChecks))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, content, border)
+}
+
+// renderBudgets lists the checks of the Angular budgets, styled by their status.
+func renderBudgets(budgets []data.BudgetResult) string {
+	lines := []string{styles.NormalText.Render(fmt.Sprintf("%sBudgets:", styles.IconStyle("💰")))}
+
+	for _, budget := range budgets {
+		switch budget.Status {
+		case data.BudgetError:
+			lines = append(lines, styles.Error.Render("   ✘ "+budget.String()))
+		case data.BudgetWarning:
+			lines = append(lines, styles.Warning.Render("   ▲ "+budget.String()))
+		case data.BudgetSkipped:
+			lines = append(lines, styles.DimText.Render("   - "+budget.String()))
+		default:
+			lines = append(lines, styles.Success.Render("   ✓ "+budget.String()))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// compressedSizes returns the gzip and brotli sizes of the bucket picked by size, e.g. " (gzip 61.2KB, brotli 52.4KB)".
+func compressedSizes(bm BundleBenchmark, size func(data.BuildStats) int64) string {
+	if bm.GzipStats == nil || bm.BrotliStats == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" (gzip %s, brotli %s)", utils.FormatFileSize(size(*bm.GzipStats)), utils.FormatFileSize(size(*bm.BrotliStats)))
+}
+
+func cancelledMessage(results int) string {
+	if results == 0 {
+		return "The benchmark was cancelled, no results were recorded."
+	}
+
+	return "The benchmark was cancelled, only the apps built before the cancellation were recorded."
 }
 
 func (m Model) viewportHeight() int {
-	return m.height - lipgloss.Height(styles.Header(resultTitle))
+	return m.height - lipgloss.Height(styles.Header(resultTitle)) - lipgloss.Height(logsHint)
 }
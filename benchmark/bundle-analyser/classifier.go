@@ -0,0 +1,303 @@
+package bundle_analyser
+
+import (
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// buildOutput holds the files of a build output, their paths relative to the output folder.
+type buildOutput struct {
+	// dir is the folder holding the files served to the browser, e.g. dist/apps/shell/browser.
+	dir string
+	// root is the output path of the build, where the bundlers write their manifests next to the browser folder.
+	root    string
+	scripts map[string]int64
+	styles  map[string]int64
+	// others holds the assets and the files left out of the stats, e.g. the source maps
+	others map[string]int64
+	// compressed holds the compressed sizes of the scripts and the styles
+	compressed map[string]compressedSize
+}
+
+// classifier tells which JavaScript files of a build output are loaded with the page, the others being lazy chunks.
+type classifier interface {
+	// Name identifies how the files were classified, e.g. "vite manifest".
+	Name() string
+	// Initial returns the initial JavaScript files. It reports false when it cannot tell them,
+	// e.g. when the manifest it reads is missing, so that the next classifier is tried.
+	Initial(output buildOutput) (map[string]bool, bool)
+}
+
+// classifiers are keyed by executor, the @nrwl scope of the older Nx versions being read as @nx.
+// The manifest of the bundler is always preferred to the naming conventions of the files.
+var classifiers = map[string][]classifier{
+	"@angular-devkit/build-angular:application":     {esbuildMetafile{}, angularNames{}},
+	"@angular-devkit/build-angular:browser":         {webpackStats{}, angularNames{}},
+	"@angular-devkit/build-angular:browser-esbuild": {esbuildMetafile{}, angularNames{}},
+	"@angular/build:application":                    {esbuildMetafile{}, angularNames{}},
+	"@nx/angular:application":                       {esbuildMetafile{}, angularNames{}},
+	"@nx/angular:browser-esbuild":                   {esbuildMetafile{}, angularNames{}},
+	"@nx/angular:webpack-browser":                   {webpackStats{}, angularNames{}},
+	"@nx/vite:build":                                {viteManifest{}, indexHTML{}, entryNames{}},
+	"@nx/webpack:webpack":                           {webpackStats{}, indexHTML{}, entryNames{}},
+	"@nx/esbuild:esbuild":                           {esbuildMetafile{}, indexHTML{}, entryNames{}},
+}
+
+// defaultClassifiers are used for the other executors, e.g. the targets inferred by the Nx plugins.
+var defaultClassifiers = []classifier{viteManifest{}, esbuildMetafile{}, webpackStats{}, indexHTML{}, entryNames{}}
+
+// classifiersOf returns the classifiers selected by the executor of the build target of the app.
+func classifiersOf(app workspace.Application) []classifier {
+	build, ok := app.Targets["build"]
+	if !ok {
+		return defaultClassifiers
+	}
+
+	if selected, ok := classifiers[strings.Replace(build.Executor, "@nrwl/", "@nx/", 1)]; ok {
+		return selected
+	}
+
+	return defaultClassifiers
+}
+
+// readBuildOutput lists the files of the output of the app, in all its sub folders.
+// The browser folder written by the Angular application builder is read when there is one.
+func readBuildOutput(cwd string, app workspace.Application) (buildOutput, error) {
+	root := filepath.Join(cwd, app.OutputPath)
+	dir := filepath.Join(root, "browser")
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		dir = root
+	}
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return buildOutput{}, utils.Errorf("Build output directory not found: %s. You're might be using an unsupported version of NX", dir)
+	}
+
+	output := buildOutput{dir: dir, root: root, scripts: map[string]int64{}, styles: map[string]int64{}, others: map[string]int64{}}
+
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch path.Ext(rel) {
+		case ".js", ".mjs", ".cjs":
+			output.scripts[rel] = info.Size()
+		case ".css":
+			output.styles[rel] = info.Size()
+		default:
+			output.others[rel] = info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return output, err
+	}
+
+	output.compressed, err = compressOutput(output)
+
+	return output, err
+}
+
+// classification holds the stats of the output in each encoding, its files and the name of the classifier that told
+// the initial files apart.
+type classification struct {
+	stats      data.BuildStats
+	gzip       data.BuildStats
+	brotli     data.BuildStats
+	files      []data.BundleFile
+	classifier string
+}
+
+// classify sorts the files of the output with the first classifier able to tell the initial files.
+// The files are listed the largest first.
+func classify(output buildOutput, selected []classifier) classification {
+	result := classification{
+		files: make([]data.BundleFile, 0, len(output.scripts)+len(output.styles)+len(output.others)),
+	}
+
+	var initial map[string]bool
+
+	for _, c := range selected {
+		if files, ok := c.Initial(output); ok {
+			initial, result.classifier = files, c.Name()
+			break
+		}
+	}
+
+	for file, size := range output.scripts {
+		category := data.FileMain
+
+		switch base := path.Base(file); {
+		case !initial[file]:
+			category = data.FileLazy
+		case strings.HasPrefix(base, "polyfills"):
+			category = data.FilePolyfills
+		case strings.HasPrefix(base, "runtime"), strings.HasPrefix(base, "scripts"):
+			category = data.FileRuntime
+		}
+
+		result.add(newBundleFile(file, category, size, output.compressed[file]))
+	}
+
+	for file, size := range output.styles {
+		result.add(newBundleFile(file, data.FileStyles, size, output.compressed[file]))
+	}
+
+	// the assets are summed up by the caller, over the same folder
+	for file, size := range output.others {
+		category := utils.Ternary(utils.IsAssetFile(file), data.FileAssets, data.FileOther)
+		result.files = append(result.files, newBundleFile(file, category, size, compressedSize{}))
+	}
+
+	sort.Slice(result.files, func(i, j int) bool {
+		if result.files[i].Size != result.files[j].Size {
+			return result.files[i].Size > result.files[j].Size
+		}
+		return result.files[i].Name < result.files[j].Name
+	})
+
+	return result
+}
+
+func (c *classification) add(file data.BundleFile) {
+	c.files = append(c.files, file)
+
+	addSize(&c.stats, file.Category, file.Size)
+	addSize(&c.gzip, file.Category, file.Gzip)
+	addSize(&c.brotli, file.Category, file.Brotli)
+}
+
+func addSize(stats *data.BuildStats, category data.FileCategory, size int64) {
+	switch category {
+	case data.FileMain:
+		stats.Initial.Main += size
+	case data.FileRuntime:
+		stats.Initial.Runtime += size
+	case data.FilePolyfills:
+		stats.Initial.Polyfills += size
+	case data.FileLazy:
+		stats.Lazy += size
+	case data.FileStyles:
+		stats.Styles += size
+	}
+}
+
+func newBundleFile(name string, category data.FileCategory, size int64, compressed compressedSize) data.BundleFile {
+	return data.BundleFile{
+		Name:           name,
+		NormalizedName: normalizeName(name),
+		Category:       category,
+		Size:           size,
+		Gzip:           compressed.gzip,
+		Brotli:         compressed.brotli,
+	}
+}
+
+// normalizeName strips the content hash from the name of a file, e.g. main-5DZ7QKTA.js, main.3f2a1b9c8d7e6f5a.js
+// and index-BfXz12_a.js.map become main.js, main.js and index.js.map, so that a file keeps its name across builds.
+func normalizeName(file string) string {
+	dir, base := path.Split(file)
+
+	suffix := ""
+	if strings.HasSuffix(base, ".map") {
+		base, suffix = strings.TrimSuffix(base, ".map"), ".map"
+	}
+
+	ext := path.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+
+	// a file named after its hash only, e.g. 3f2a1b9c8d7e6f5a.js, keeps its name
+	separator := strings.LastIndexAny(stem, "-.")
+	if separator < 1 || !isHash(stem[separator+1:]) {
+		return file
+	}
+
+	return dir + stem[:separator] + ext + suffix
+}
+
+// isHash tells the hashes of esbuild, Vite and webpack, at least 8 letters, digits or underscores, from the words
+// of a file name by the digits or capital letters they hold.
+func isHash(segment string) bool {
+	if len(segment) < 8 {
+		return false
+	}
+
+	mixed := false
+	for _, r := range segment {
+		switch {
+		case r >= '0' && r <= '9', r >= 'A' && r <= 'Z':
+			mixed = true
+		case r >= 'a' && r <= 'z', r == '_':
+		default:
+			return false
+		}
+	}
+
+	return mixed
+}
+
+// angularEntries are the prefixes of the scripts the Angular builders load with the page.
+var angularEntries = []string{"main", "polyfills", "runtime", "scripts"}
+
+// angularNames follows the naming conventions of the Angular builders, where the initial files are written at the top
+// of the output as main, polyfills, runtime and scripts. The other scripts, e.g. the lazy chunks, the web workers and
+// the scripts of the assets, aren't loaded with the page.
+type angularNames struct{}
+
+func (angularNames) Name() string { return "angular naming" }
+
+func (angularNames) Initial(output buildOutput) (map[string]bool, bool) {
+	initial := map[string]bool{}
+
+	for file := range output.scripts {
+		if strings.Contains(file, "/") {
+			continue
+		}
+
+		if slices.ContainsFunc(angularEntries, func(prefix string) bool { return strings.HasPrefix(file, prefix) }) {
+			initial[file] = true
+		}
+	}
+
+	return initial, true
+}
+
+// entryNames is the last resort for the bundlers without a manifest or an index.html, e.g. an esbuild bundle of a
+// Node application: the files at the top of the output are the entry points, unless they are named chunk-<hash>.js.
+type entryNames struct{}
+
+func (entryNames) Name() string { return "file names" }
+
+func (entryNames) Initial(output buildOutput) (map[string]bool, bool) {
+	initial := map[string]bool{}
+
+	for file := range output.scripts {
+		if !strings.Contains(file, "/") && !strings.HasPrefix(file, "chunk-") {
+			initial[file] = true
+		}
+	}
+
+	return initial, true
+}
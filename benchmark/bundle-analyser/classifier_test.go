@@ -0,0 +1,30 @@
+package bundle_analyser
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"main-5DZ7QKTA.js", "main.js"},
+		{"main.3f2a1b9c8d7e6f5a.js", "main.js"},
+		{"index-BfXz12_a.js.map", "index.js.map"},
+		{"styles-XKJ2ZPLM.css", "styles.css"},
+		{"my-component-A1B2C3D4.js", "my-component.js"},
+		{"media/logo-5DZ7QKTA.svg", "media/logo.svg"},
+		// a file named after its hash only keeps its name
+		{"3f2a1b9c8d7e6f5a.js", "3f2a1b9c8d7e6f5a.js"},
+		// words and short segments are not hashes
+		{"polyfills-abcdefgh.js", "polyfills-abcdefgh.js"},
+		{"chunk-ABC123.js", "chunk-ABC123.js"},
+		{"runtime.js", "runtime.js"},
+		{"favicon.ico", "favicon.ico"},
+	}
+
+	for _, test := range tests {
+		if got := normalizeName(test.file); got != test.want {
+			t.Errorf("normalizeName(%q) = %q, want %q", test.file, got, test.want)
+		}
+	}
+}
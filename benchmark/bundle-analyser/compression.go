@@ -0,0 +1,100 @@
+package bundle_analyser
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// compressedSize holds the sizes of a file once compressed the way a server precompresses the static files,
+// with the best compression level of each encoding.
+type compressedSize struct {
+	gzip   int64
+	brotli int64
+}
+
+// counter is a writer counting the bytes written to it.
+type counter int64
+
+func (c *counter) Write(p []byte) (int, error) {
+	*c += counter(len(p))
+	return len(p), nil
+}
+
+// compressOutput measures the compressed sizes of the JavaScript and CSS files of the output,
+// spread over as many workers as there are CPUs since brotli is slow at its best level.
+func compressOutput(output buildOutput) (map[string]compressedSize, error) {
+	var files []string
+	for file := range output.scripts {
+		files = append(files, file)
+	}
+	for file := range output.styles {
+		files = append(files, file)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		sizes    = make(map[string]compressedSize, len(files))
+		queue    = make(chan string)
+	)
+
+	for i := 0; i < min(runtime.NumCPU(), len(files)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for file := range queue {
+				size, err := compress(filepath.Join(output.dir, filepath.FromSlash(file)))
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				sizes[file] = size
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, file := range files {
+		queue <- file
+	}
+	close(queue)
+
+	wg.Wait()
+
+	return sizes, firstErr
+}
+
+func compress(file string) (compressedSize, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return compressedSize{}, err
+	}
+
+	var gzipSize, brotliSize counter
+
+	gzipWriter, err := gzip.NewWriterLevel(&gzipSize, gzip.BestCompression)
+	if err != nil {
+		return compressedSize{}, err
+	}
+
+	brotliWriter := brotli.NewWriterLevel(&brotliSize, brotli.BestCompression)
+
+	for _, writer := range []io.WriteCloser{gzipWriter, brotliWriter} {
+		if _, err := writer.Write(content); err != nil {
+			return compressedSize{}, err
+		}
+		if err := writer.Close(); err != nil {
+			return compressedSize{}, err
+		}
+	}
+
+	return compressedSize{gzip: int64(gzipSize), brotli: int64(brotliSize)}, nil
+}
@@ -0,0 +1,268 @@
+package bundle_analyser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// manifest returns the content of the first file found in the browser folder or in the output path of the build.
+func (o buildOutput) manifest(names ...string) ([]byte, bool) {
+	for _, name := range names {
+		for _, dir := range []string{o.dir, o.root} {
+			if content, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+				return content, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// script returns the JavaScript file of the output a manifest refers to, whether the path of the manifest
+// is relative to the output folder, to the workspace root or to the base URL of the app.
+func (o buildOutput) script(ref string) (string, bool) {
+	ref = strings.TrimLeft(strings.TrimPrefix(ref, "./"), "/")
+
+	if _, ok := o.scripts[ref]; ok {
+		return ref, true
+	}
+
+	// the longest file wins, e.g. assets/index.js over index.js
+	match := ""
+	for file := range o.scripts {
+		if strings.HasSuffix(ref, "/"+file) && len(file) > len(match) {
+			match = file
+		}
+	}
+
+	return match, match != ""
+}
+
+// viteManifest reads the manifest.json written by Vite with build.manifest, in the .vite folder since Vite 5.
+// The entries and the chunks they import statically are initial, the dynamic imports are lazy.
+type viteManifest struct{}
+
+type viteChunk struct {
+	File    string   `json:"file"`
+	IsEntry bool     `json:"isEntry"`
+	Imports []string `json:"imports"`
+}
+
+func (viteManifest) Name() string { return "vite manifest" }
+
+func (viteManifest) Initial(output buildOutput) (map[string]bool, bool) {
+	content, ok := output.manifest(".vite/manifest.json", "manifest.json")
+	if !ok {
+		return nil, false
+	}
+
+	// the manifest.json of a PWA doesn't hold chunks
+	var chunks map[string]viteChunk
+	if err := json.Unmarshal(content, &chunks); err != nil {
+		return nil, false
+	}
+
+	var queue []string
+	for key, chunk := range chunks {
+		if chunk.IsEntry {
+			queue = append(queue, key)
+		}
+	}
+
+	if len(queue) == 0 {
+		return nil, false
+	}
+
+	initial := map[string]bool{}
+	visited := map[string]bool{}
+
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		if file, ok := output.script(chunks[key].File); ok {
+			initial[file] = true
+		}
+
+		queue = append(queue, chunks[key].Imports...)
+	}
+
+	return initial, true
+}
+
+// esbuildMetafile reads the metafile of esbuild, written as meta.json by the Nx esbuild executor
+// and as stats.json by the Angular application builder with statsJson.
+// The entry points and the outputs they import statically are initial, the outputs only imported dynamically are lazy.
+type esbuildMetafile struct{}
+
+type esbuildOutput struct {
+	EntryPoint string `json:"entryPoint"`
+	Imports    []struct {
+		Path string `json:"path"`
+		Kind string `json:"kind"`
+	} `json:"imports"`
+}
+
+func (esbuildMetafile) Name() string { return "esbuild metafile" }
+
+func (esbuildMetafile) Initial(output buildOutput) (map[string]bool, bool) {
+	content, ok := output.manifest("meta.json", "metafile.json", "stats.json")
+	if !ok {
+		return nil, false
+	}
+
+	var metafile struct {
+		Outputs map[string]esbuildOutput `json:"outputs"`
+	}
+	if err := json.Unmarshal(content, &metafile); err != nil || len(metafile.Outputs) == 0 {
+		return nil, false
+	}
+
+	dynamic := map[string]bool{}
+	for _, out := range metafile.Outputs {
+		for _, imported := range out.Imports {
+			if imported.Kind == "dynamic-import" {
+				dynamic[imported.Path] = true
+			}
+		}
+	}
+
+	var queue []string
+	for name, out := range metafile.Outputs {
+		if out.EntryPoint != "" && !dynamic[name] {
+			queue = append(queue, name)
+		}
+	}
+
+	initial := map[string]bool{}
+	visited := map[string]bool{}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+
+		if file, ok := output.script(name); ok {
+			initial[file] = true
+		}
+
+		for _, imported := range metafile.Outputs[name].Imports {
+			if imported.Kind == "import-statement" || imported.Kind == "require-call" {
+				queue = append(queue, imported.Path)
+			}
+		}
+	}
+
+	return initial, len(initial) > 0
+}
+
+// webpackStats reads the stats.json written by webpack with --stats-json or by the Angular browser builder with statsJson.
+// The files of the initial chunks are initial, or the assets of the entry points when the chunks were left out of the stats.
+type webpackStats struct{}
+
+func (webpackStats) Name() string { return "webpack stats" }
+
+func (webpackStats) Initial(output buildOutput) (map[string]bool, bool) {
+	content, ok := output.manifest("stats.json")
+	if !ok {
+		return nil, false
+	}
+
+	var stats struct {
+		Chunks []struct {
+			Initial bool     `json:"initial"`
+			Files   []string `json:"files"`
+		} `json:"chunks"`
+		Entrypoints map[string]struct {
+			Assets []json.RawMessage `json:"assets"`
+		} `json:"entrypoints"`
+	}
+	if err := json.Unmarshal(content, &stats); err != nil {
+		return nil, false
+	}
+
+	initial := map[string]bool{}
+
+	for _, chunk := range stats.Chunks {
+		for _, ref := range chunk.Files {
+			if file, ok := output.script(ref); ok && chunk.Initial {
+				initial[file] = true
+			}
+		}
+	}
+
+	if len(stats.Chunks) > 0 {
+		return initial, len(initial) > 0
+	}
+
+	// the assets of an entry point are names in webpack 4 and objects in webpack 5
+	for _, entrypoint := range stats.Entrypoints {
+		for _, raw := range entrypoint.Assets {
+			var name string
+			if err := json.Unmarshal(raw, &name); err != nil {
+				var asset struct {
+					Name string `json:"name"`
+				}
+				_ = json.Unmarshal(raw, &asset)
+				name = asset.Name
+			}
+
+			if file, ok := output.script(name); ok {
+				initial[file] = true
+			}
+		}
+	}
+
+	return initial, len(initial) > 0
+}
+
+var (
+	scriptTag     = regexp.MustCompile(`(?i)<script\b[^>]*\bsrc=["']([^"']+)["']`)
+	modulePreload = regexp.MustCompile(`(?i)<link\b[^>]*\brel=["']modulepreload["'][^>]*>`)
+	hrefAttribute = regexp.MustCompile(`(?i)\bhref=["']([^"']+)["']`)
+)
+
+// indexHTML takes the scripts and the module preloads of index.html as the initial files,
+// which is how the bundlers without a manifest load the entry chunks and the chunks they import.
+type indexHTML struct{}
+
+func (indexHTML) Name() string { return "index.html" }
+
+func (indexHTML) Initial(output buildOutput) (map[string]bool, bool) {
+	content, err := os.ReadFile(filepath.Join(output.dir, "index.html"))
+	if err != nil {
+		return nil, false
+	}
+
+	var refs []string
+	for _, match := range scriptTag.FindAllStringSubmatch(string(content), -1) {
+		refs = append(refs, match[1])
+	}
+
+	for _, link := range modulePreload.FindAllString(string(content), -1) {
+		if match := hrefAttribute.FindStringSubmatch(link); match != nil {
+			refs = append(refs, match[1])
+		}
+	}
+
+	initial := map[string]bool{}
+	for _, ref := range refs {
+		if file, ok := output.script(ref); ok {
+			initial[file] = true
+		}
+	}
+
+	return initial, len(initial) > 0
+}
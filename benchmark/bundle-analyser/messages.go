@@ -1,49 +1,20 @@
 package bundle_analyser
 
 import (
+	data "github.com/ionut-t/gonx/benchmark/data"
 	"github.com/ionut-t/gonx/workspace"
 	"time"
 )
 
 type StartMsg struct {
-	Apps        []workspace.Application
-	Description string
-	StartTime   time.Time
+	Apps          []workspace.Application
+	Description   string
+	TargetOptions data.TargetOptions
+	StartTime     time.Time
 }
 
-type TotalProcessesMsg int
-
-type NxCacheResetStartMsg struct {
-	StartTime time.Time
-}
-
-type BuildStartMsg struct {
-	App       string
-	StartTime time.Time
-}
-
-type CalculateBundleSizeMsg struct {
-	App       string
-	StartTime time.Time
-}
-
-type WriteStatsMsg struct {
-	App      string
-	StartMsg time.Time
-}
-
-type BuildCompleteMsg struct {
-	App       string
-	Error     error
-	EndTime   time.Time
-	Benchmark BundleBenchmark
-}
-
-type BuildFailedMsg struct {
-	App     string
-	EndTime time.Time
-	Error   error
-}
+// CompleteMsg is sent once the event stream of the benchmark is closed.
+type CompleteMsg struct{}
 
 type DoneMsg struct {
 	Error error
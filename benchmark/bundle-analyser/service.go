@@ -1,25 +1,23 @@
 package bundle_analyser
 
 import (
-	"encoding/json"
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/google/uuid"
+	"context"
+	"github.com/ionut-t/gonx/benchmark/budget"
 	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
 	"github.com/ionut-t/gonx/internal/constants"
 	"github.com/ionut-t/gonx/utils"
 	"github.com/ionut-t/gonx/workspace"
 	"log"
 	"os"
-	"os/exec"
-	"strings"
 	"time"
 )
 
 type BundleBenchmark data.BundleBenchmark
 
+// calculateBundleSize sorts the files of the build output of the app into initial and lazy bundles,
+// with the classifiers selected by the executor of its build target.
 func (b *BundleBenchmark) calculateBundleSize(app workspace.Application) (*data.BuildStats, error) {
-	stats := data.BuildStats{}
-
 	cwd, err := os.Getwd()
 
 	if err != nil {
@@ -27,184 +25,105 @@ func (b *BundleBenchmark) calculateBundleSize(app workspace.Application) (*data.
 		return nil, err
 	}
 
-	path := cwd + "/" + app.OutputPath + "/browser"
-
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		path = cwd + "/" + app.OutputPath
+	output, err := readBuildOutput(cwd, app)
+	if err != nil {
+		return nil, err
 	}
 
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, utils.Errorf("Build output directory not found: %s. You're might be using an unsupported version of NX", path)
-	}
+	result := classify(output, classifiersOf(app))
+	b.Classifier = result.classifier
+	b.Files = result.files
 
-	if assetsSize, err := utils.FindAndCalculateAssetsSize(path); !os.IsNotExist(err) {
-		stats.Assets = assetsSize
+	if packages, ok := composition(output); ok {
+		b.Composition = packages
 	}
 
-	files, _ := os.ReadDir(path)
-
-	for _, file := range files {
-		if !strings.HasSuffix(file.Name(), ".js") &&
-			!strings.HasSuffix(file.Name(), ".css") {
-			continue
-		}
-
-		stat, _ := file.Info()
-
-		if !stat.IsDir() {
-			size := stat.Size()
-
-			if strings.HasPrefix(file.Name(), "main") {
-				stats.Initial.Main += size
-			} else if strings.HasPrefix(file.Name(), "scripts") {
-				stats.Initial.Runtime += size
-			} else if strings.HasPrefix(file.Name(), "polyfills") {
-				stats.Initial.Polyfills += size
-			} else if strings.HasPrefix(file.Name(), "chunk-") || strings.Contains(file.Name(), "chunk") {
-				stats.Lazy += size
-			} else if strings.HasSuffix(file.Name(), ".css") {
-				stats.Styles += size
-			}
-		}
+	var assets int64
+	if assetsSize, err := utils.FindAndCalculateAssetsSize(output.dir); !os.IsNotExist(err) {
+		assets = assetsSize
 	}
 
+	// the assets, mostly images and fonts, are served as they are
+	stats := withTotals(result.stats, assets)
+	gzipStats := withTotals(result.gzip, assets)
+	brotliStats := withTotals(result.brotli, assets)
+
+	b.GzipStats = &gzipStats
+	b.BrotliStats = &brotliStats
+
+	b.Stats = stats
+	b.Budgets = b.checkBudgets(app)
+
+	return &stats, nil
+}
+
+func withTotals(stats data.BuildStats, assets int64) data.BuildStats {
+	stats.Assets = assets
 	stats.Initial.Total = stats.Initial.Main + stats.Initial.Runtime + stats.Initial.Polyfills
 	stats.Total = stats.Initial.Total + stats.Lazy
 	stats.OverallTotal = stats.Total + stats.Assets + stats.Styles
 
-	return &stats, nil
+	return stats
 }
 
 func (b *BundleBenchmark) WriteStats(appName string, startTime time.Time) error {
 	b.AppName = appName
 	b.CreatedAt = time.Now()
-	b.ID = uuid.New().String()
 	b.Duration = time.Since(startTime).Seconds()
 
-	benchmark, err := utils.ToJsonString(b)
-
-	if err != nil {
-		return err
-	}
-
-	var results []json.RawMessage
-	currentValue, err := os.ReadFile(constants.BundleAnalyserFilePath)
-
-	if err == nil && len(currentValue) > 0 {
-		if err := json.Unmarshal(currentValue, &results); err != nil {
-			return err
-		}
-	}
-
-	results = append([]json.RawMessage{json.RawMessage(benchmark)}, results...)
-
-	content, err := json.MarshalIndent(results, "", "  ")
-
-	if err != nil {
-		return err
-	}
-
-	err = os.MkdirAll(constants.BenchmarkFolderPath, 0755)
-
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(constants.BundleAnalyserFilePath, content, 0644)
+	return data.Prepend(constants.BundleAnalyserFilePath, b)
 }
 
-func startBenchmark(apps []workspace.Application, description string) tea.Cmd {
-	// - Global messages: TotalProcessesMsg, NxCacheResetStartMsg, (2 total)
-	// - For each app: BuildStartMsg, CalculateBundleSizeMsg, WriteStatsMsg, BuildCompleteMsg/BuildFailedMsg (4 per app)
-	totalProcesses := 2 + len(apps)*4
-
-	// Create channel for build results
-	results := make(chan tea.Msg, totalProcesses)
-
-	go func() {
-		defer close(results)
-
-		results <- TotalProcessesMsg(totalProcesses - 1) // -1 for this message
-
-		results <- NxCacheResetStartMsg{}
-
-		cmdReset := exec.Command("nx", "reset")
-		cmdReset.Env = append(os.Environ(), "NX_DAEMON=false")
-		if err := cmdReset.Run(); err != nil {
-			// If reset fails, send failed messages for all apps
-			for _, app := range apps {
-				results <- BuildFailedMsg{
-					App:   app.Name,
-					Error: utils.Errorf("Workspace reset failed: %v", err),
-				}
-			}
-			return
-		}
-
-		// Run builds sequentially
-		for _, app := range apps {
-			startTime := time.Now()
-			benchmark := BundleBenchmark{Description: description}
-
-			// Send startBenchmark message
-			results <- BuildStartMsg{
-				App:       app.Name,
-				StartTime: startTime,
-			}
+// Run starts the bundle benchmark for the given apps and returns its event stream.
+// The Nx cache is reset once, then every app is built with the given options and its bundle size recorded.
+// The suite run tags the benchmarks recorded by a suite and is empty otherwise.
+func Run(ctx context.Context, apps []workspace.Application, description string, options data.TargetOptions, suite data.SuiteRun) <-chan runner.Event {
+	projects := make([]workspace.Project, 0, len(apps))
+	for _, app := range apps {
+		projects = append(projects, app)
+	}
 
-			// Run build
-			cmdBuild := exec.Command("nx", "build", app.Name)
-			cmdBuild.Env = append(os.Environ(), "NX_DAEMON=false")
-			if err := cmdBuild.Run(); err != nil {
-				results <- BuildFailedMsg{
-					App:     app.Name,
-					EndTime: time.Now(),
-					Error:   utils.Errorf("Build failed for %s with: %v", app, err),
-				}
-				continue // Continue with next app even if one fails
+	// the budgets are read once for all the apps, which are still benchmarked when they can't be read
+	budgets, budgetErr := budget.Budgets()
+
+	return runner.Start(ctx, runner.Options[BundleBenchmark]{
+		Settings: runner.Settings{
+			Description:   description,
+			Runs:          1,
+			Cache:         data.Cache{CacheMode: data.CacheResetOnce},
+			TargetOptions: options,
+			Suite:         suite,
+		},
+		Projects: projects,
+		Target:   "build",
+		// the bundles are only written by a succeeded build
+		SuccessRequired: true,
+		Finish: func(result runner.Result) (BundleBenchmark, error) {
+			benchmark := BundleBenchmark{
+				ID:            result.ID.String(),
+				Description:   description,
+				Log:           result.Runs[0].Log,
+				TargetOptions: options,
+				SuiteRun:      suite,
 			}
 
-			results <- CalculateBundleSizeMsg{App: app.Name, StartTime: time.Now()}
-
-			stats, err := benchmark.calculateBundleSize(app)
+			stats, err := benchmark.calculateBundleSize(result.Project.(workspace.Application))
 			if err != nil {
-				results <- BuildFailedMsg{
-					App:     app.Name,
-					EndTime: time.Now(),
-					Error:   utils.Errorf("Bundle size calculation failed: %v", err),
-				}
-				continue
+				return benchmark, utils.Errorf("Bundle size calculation failed: %v", err)
 			}
 			benchmark.Stats = *stats
 
-			results <- WriteStatsMsg{App: app.Name, StartMsg: time.Now()}
+			benchmark.BudgetChecks = budget.CheckSizes(budgets, result.Project, benchmark.Stats)
 
-			err = benchmark.WriteStats(app.Name, startTime)
-			if err != nil {
-				results <- BuildFailedMsg{
-					App:     app.Name,
-					EndTime: time.Now(),
-					Error:   utils.Errorf("Failed to write stats: %v", err),
-				}
-				continue
+			if err := benchmark.WriteStats(result.Project.GetName(), result.StartTime); err != nil {
+				return benchmark, utils.Errorf("Failed to write stats: %v", err)
 			}
 
-			results <- BuildCompleteMsg{
-				App:       app.Name,
-				Error:     nil,
-				EndTime:   time.Now(),
-				Benchmark: benchmark,
+			if budgetErr != nil {
+				return benchmark, utils.Errorf("Failed to check the performance budgets: %v", budgetErr)
 			}
-		}
-	}()
-
-	// Create commands to read all expected messages
-	var cmds []tea.Cmd
-	for i := 0; i < totalProcesses; i++ {
-		cmds = append(cmds, func() tea.Msg {
-			return <-results
-		})
-	}
 
-	return tea.Batch(cmds...)
+			return benchmark, nil
+		},
+	})
 }
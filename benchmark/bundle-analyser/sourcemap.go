@@ -0,0 +1,301 @@
+package bundle_analyser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// workspacePackage holds the sources of the workspace, outside of node_modules
+	workspacePackage = "[workspace]"
+	// unmappedPackage holds the bytes no mapping points back to a source, e.g. the glue code of the bundler
+	unmappedPackage = "[unmapped]"
+	// noSourceMapPackage holds the scripts emitted without a source map
+	noSourceMapPackage = "[no source map]"
+)
+
+var sourceMappingURL = regexp.MustCompile(`(?m)^//[#@] sourceMappingURL=(\S+)\s*$`)
+
+// sourceMap is the part of a source map, version 3, needed to tell which source each generated byte comes from.
+type sourceMap struct {
+	Sources    []string `json:"sources"`
+	SourceRoot string   `json:"sourceRoot"`
+	Mappings   string   `json:"mappings"`
+}
+
+// composition attributes the bytes of the JavaScript files of the output to the modules and packages they were
+// bundled from, with the source maps written next to them, the way source-map-explorer does.
+// It reports false when none of the files has a source map.
+func composition(output buildOutput) ([]data.PackageSize, bool) {
+	modules := map[string]map[string]int64{}
+	mapped := false
+
+	add := func(pkg, module string, size int64) {
+		if size == 0 {
+			return
+		}
+		if modules[pkg] == nil {
+			modules[pkg] = map[string]int64{}
+		}
+		modules[pkg][module] += size
+	}
+
+	for file, size := range output.scripts {
+		content, err := os.ReadFile(filepath.Join(output.dir, filepath.FromSlash(file)))
+		if err != nil {
+			add(noSourceMapPackage, file, size)
+			continue
+		}
+
+		sm, ok := readSourceMap(output.dir, file, content)
+		if !ok {
+			add(noSourceMapPackage, file, size)
+			continue
+		}
+
+		mapped = true
+
+		sizes, unmapped := attribute(sm, content)
+		for source, bytes := range sizes {
+			pkg, module := packageOf(source)
+			add(pkg, module, bytes)
+		}
+		add(unmappedPackage, file, unmapped)
+	}
+
+	if !mapped {
+		return nil, false
+	}
+
+	packages := make([]data.PackageSize, 0, len(modules))
+
+	for name, sizes := range modules {
+		pkg := data.PackageSize{Name: name, Modules: make([]data.ModuleSize, 0, len(sizes))}
+
+		for module, size := range sizes {
+			pkg.Size += size
+			pkg.Modules = append(pkg.Modules, data.ModuleSize{Path: module, Size: size})
+		}
+
+		sort.Slice(pkg.Modules, func(i, j int) bool {
+			if pkg.Modules[i].Size != pkg.Modules[j].Size {
+				return pkg.Modules[i].Size > pkg.Modules[j].Size
+			}
+			return pkg.Modules[i].Path < pkg.Modules[j].Path
+		})
+
+		packages = append(packages, pkg)
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Size != packages[j].Size {
+			return packages[i].Size > packages[j].Size
+		}
+		return packages[i].Name < packages[j].Name
+	})
+
+	return packages, true
+}
+
+// readSourceMap reads the source map the script refers to with its sourceMappingURL comment, inline or not,
+// or the <script>.map file next to it otherwise.
+func readSourceMap(dir, file string, content []byte) (sourceMap, bool) {
+	var raw []byte
+
+	if match := sourceMappingURL.FindSubmatch(content); match != nil {
+		url := string(match[1])
+
+		if strings.HasPrefix(url, "data:") {
+			if _, encoded, found := strings.Cut(url, ";base64,"); found {
+				raw, _ = base64.StdEncoding.DecodeString(encoded)
+			}
+		} else if !strings.Contains(url, "://") {
+			raw, _ = os.ReadFile(filepath.Join(dir, filepath.FromSlash(path.Join(path.Dir(file), url))))
+		}
+	}
+
+	if raw == nil {
+		raw, _ = os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)+".map"))
+	}
+
+	var sm sourceMap
+	if raw == nil || json.Unmarshal(raw, &sm) != nil || sm.Mappings == "" {
+		return sourceMap{}, false
+	}
+
+	return sm, true
+}
+
+// attribute counts the bytes of the generated code mapped to each source, a mapping covering the code up to the next
+// one on the same line. The bytes before the first mapping of a line, of the mappings without a source and of the line
+// breaks are returned as unmapped.
+func attribute(sm sourceMap, content []byte) (map[string]int64, int64) {
+	sizes := map[string]int64{}
+	lines := bytes.Split(content, []byte("\n"))
+	mappings := strings.Split(sm.Mappings, ";")
+	unmapped := int64(len(lines) - 1)
+
+	// the index of the source is relative to the previous segment, across the lines
+	sourceIndex := 0
+
+	for i, line := range lines {
+		offsets := byteOffsets(line)
+
+		// the bytes up to cursor are counted, the ones after belong to source, -1 for none
+		cursor, source, column := 0, -1, 0
+
+		count := func(end int) {
+			if source >= 0 && source < len(sm.Sources) {
+				sizes[sm.Sources[source]] += int64(end - cursor)
+			} else {
+				unmapped += int64(end - cursor)
+			}
+			cursor = end
+		}
+
+		if i < len(mappings) {
+			for _, segment := range strings.Split(mappings[i], ",") {
+				fields, ok := decodeVLQ(segment)
+				if !ok || len(fields) == 0 {
+					continue
+				}
+
+				column += fields[0]
+				if position := offsets(column); position > cursor {
+					count(position)
+				}
+
+				source = -1
+				if len(fields) >= 4 {
+					sourceIndex += fields[1]
+					source = sourceIndex
+				}
+			}
+		}
+
+		count(len(line))
+	}
+
+	normalized := make(map[string]int64, len(sizes))
+	for source, size := range sizes {
+		normalized[normalizeSource(sm.SourceRoot, source)] += size
+	}
+
+	return normalized, unmapped
+}
+
+// byteOffsets returns the offset in bytes of a column of the line, counted in UTF-16 code units by the source maps.
+func byteOffsets(line []byte) func(column int) int {
+	ascii := true
+	for _, b := range line {
+		if b >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+
+	if ascii {
+		return func(column int) int {
+			return min(max(column, 0), len(line))
+		}
+	}
+
+	var offsets []int
+	for i, r := range string(line) {
+		offsets = append(offsets, i)
+		// the runes outside of the basic multilingual plane take two code units
+		if r > 0xFFFF {
+			offsets = append(offsets, i)
+		}
+	}
+
+	return func(column int) int {
+		if column < 0 {
+			return 0
+		}
+		if column >= len(offsets) {
+			return len(line)
+		}
+		return offsets[column]
+	}
+}
+
+const base64Digits = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// decodeVLQ decodes the base64 VLQ values of a segment of the mappings.
+func decodeVLQ(segment string) ([]int, bool) {
+	var (
+		values []int
+		value  int
+		shift  uint
+	)
+
+	for i := 0; i < len(segment); i++ {
+		digit := strings.IndexByte(base64Digits, segment[i])
+		if digit == -1 {
+			return nil, false
+		}
+
+		value += (digit & 31) << shift
+
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+
+		if value&1 == 1 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+
+	return values, shift == 0
+}
+
+// normalizeSource turns the path of a source as written by the bundler, e.g. webpack:///./src/main.ts or
+// ../../node_modules/react/index.js, into a path relative to the workspace.
+func normalizeSource(root, source string) string {
+	if root != "" && !strings.Contains(source, "://") {
+		source = strings.TrimSuffix(root, "/") + "/" + source
+	}
+
+	if scheme := strings.Index(source, "://"); scheme != -1 {
+		source = source[scheme+3:]
+		// the namespace of webpack, e.g. webpack://shell/./src/main.ts
+		if slash := strings.Index(source, "/"); slash != -1 {
+			source = source[slash+1:]
+		}
+	}
+
+	source = path.Clean("/" + strings.ReplaceAll(source, "\\", "/"))
+
+	return strings.TrimPrefix(source, "/")
+}
+
+// packageOf returns the npm package a source belongs to, with its path in the package, or the workspace with the
+// path of the source when it isn't in node_modules.
+func packageOf(source string) (string, string) {
+	index := strings.LastIndex(source, "node_modules/")
+	if index == -1 {
+		return workspacePackage, source
+	}
+
+	parts := strings.SplitN(source[index+len("node_modules/"):], "/", 3)
+
+	if strings.HasPrefix(parts[0], "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1], strings.Join(parts[2:], "/")
+	}
+
+	return parts[0], strings.Join(parts[1:], "/")
+}
@@ -0,0 +1,101 @@
+package bundle_analyser
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestDecodeVLQ(t *testing.T) {
+	tests := []struct {
+		segment string
+		want    []int
+		ok      bool
+	}{
+		{"", nil, true},
+		{"AAAA", []int{0, 0, 0, 0}, true},
+		{"AACA", []int{0, 0, 1, 0}, true},
+		{"D", []int{-1}, true},
+		{"gBAAgB", []int{16, 0, 0, 16}, true},
+		{"hB", []int{-16}, true},
+		{"2HwBAAA", []int{123, 24, 0, 0, 0}, true},
+		// a continuation bit without the digit that ends the value
+		{"g", nil, false},
+		{"A!", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.segment, func(t *testing.T) {
+			got, ok := decodeVLQ(test.segment)
+			if ok != test.ok || ok && !slices.Equal(got, test.want) {
+				t.Errorf("decodeVLQ(%q) = %v, %v, want %v, %v", test.segment, got, ok, test.want, test.ok)
+			}
+		})
+	}
+}
+
+func TestAttribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		sm       sourceMap
+		content  string
+		want     map[string]int64
+		unmapped int64
+	}{
+		{
+			name:    "segments of one line",
+			sm:      sourceMap{Sources: []string{"a.ts", "b.ts"}, Mappings: "AAAA,KCAA"},
+			content: "hello world",
+			want:    map[string]int64{"a.ts": 5, "b.ts": 6},
+		},
+		{
+			name:     "source index relative across lines",
+			sm:       sourceMap{Sources: []string{"a.ts", "b.ts"}, Mappings: "AAAA;ACAA"},
+			content:  "abc\ndef",
+			want:     map[string]int64{"a.ts": 3, "b.ts": 3},
+			unmapped: 1,
+		},
+		{
+			name:     "bytes before the first mapping",
+			sm:       sourceMap{Sources: []string{"a.ts"}, Mappings: "EAAA"},
+			content:  "abcdef",
+			want:     map[string]int64{"a.ts": 4},
+			unmapped: 2,
+		},
+		{
+			name:     "segment without a source",
+			sm:       sourceMap{Sources: []string{"a.ts"}, Mappings: "AAAA,G"},
+			content:  "abcdef",
+			want:     map[string]int64{"a.ts": 3},
+			unmapped: 3,
+		},
+		{
+			name:     "line without mappings",
+			sm:       sourceMap{Sources: []string{"a.ts"}, Mappings: "AAAA"},
+			content:  "abc\ndef",
+			want:     map[string]int64{"a.ts": 3},
+			unmapped: 4,
+		},
+		{
+			name:    "columns in utf-16 code units",
+			sm:      sourceMap{Sources: []string{"a.ts", "b.ts"}, Mappings: "AAAA,CCAA"},
+			content: "é=1",
+			want:    map[string]int64{"a.ts": 2, "b.ts": 2},
+		},
+		{
+			name:    "sources normalized and merged",
+			sm:      sourceMap{Sources: []string{"webpack:///./src/main.ts", "src/main.ts"}, Mappings: "AAAA,ECAA"},
+			content: "abcd",
+			want:    map[string]int64{"src/main.ts": 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, unmapped := attribute(test.sm, []byte(test.content))
+			if !maps.Equal(got, test.want) || unmapped != test.unmapped {
+				t.Errorf("attribute(%q) = %v, %d unmapped, want %v, %d unmapped", test.content, got, unmapped, test.want, test.unmapped)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package benchmark_data
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/ionut-t/gonx/utils"
 	"github.com/ionut-t/gonx/workspace"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -14,8 +18,29 @@ type BundleBenchmark struct {
 	Duration    float64    `json:"duration"`
 	Description string     `json:"description"`
 	Stats       BuildStats `json:"stats"`
+	// GzipStats and BrotliStats hold the compressed sizes of the JavaScript and the CSS, the assets being counted as they are.
+	// They are nil for the benchmarks recorded before the compressed sizes were measured.
+	GzipStats   *BuildStats `json:"gzipStats,omitempty"`
+	BrotliStats *BuildStats `json:"brotliStats,omitempty"`
+	// Classifier tells how the initial files were told apart from the lazy chunks, e.g. "vite manifest".
+	// It is empty for the benchmarks recorded before the bundlers other than Angular were supported.
+	Classifier string `json:"classifier,omitempty"`
+	// Files lists every file of the build output, the largest first.
+	Files []BundleFile `json:"files,omitempty"`
+	// Composition lists the packages the JavaScript was bundled from, the largest first.
+	// It is empty when the build didn't emit source maps.
+	Composition []PackageSize `json:"composition,omitempty"`
+	// Budgets are the Angular budgets of the build configuration, checked against the output.
+	Budgets []BudgetResult `json:"budgets,omitempty"`
+	// BudgetChecks are the performance budgets of the gonx configuration checked against the sizes.
+	BudgetChecks BudgetChecks `json:"budgetChecks,omitempty"`
+	Log          string       `json:"log,omitempty"`
+	TargetOptions
+	SuiteRun
 }
 
+// InitialStats holds the sizes of the JavaScript loaded with the page. Main holds every initial file
+// other than the runtime and the polyfills, e.g. the vendor chunks of webpack or Vite.
 type InitialStats struct {
 	Main      int64 `json:"main"`
 	Runtime   int64 `json:"runtime"`
@@ -36,40 +61,611 @@ func (stats *BuildStats) String() string {
 	return utils.PrettyJSON(stats)
 }
 
-type BuildBenchmark struct {
-	ID          uuid.UUID `json:"id"`
-	AppName     string    `json:"appName"`
-	CreatedAt   time.Time `json:"createdAt"`
-	Duration    float64   `json:"duration"`
-	Description string    `json:"description"`
-	Min         float64   `json:"min"`
-	Max         float64   `json:"max"`
-	Average     float64   `json:"avg"`
-	TotalRuns   int       `json:"totalRuns"`
+// Compression is the encoding the sizes of a bundle benchmark are read in.
+type Compression string
+
+const (
+	CompressionNone   Compression = "raw"
+	CompressionGzip   Compression = "gzip"
+	CompressionBrotli Compression = "brotli"
+)
+
+// Compressions lists the encodings in the order the views cycle through them.
+var Compressions = []Compression{CompressionNone, CompressionGzip, CompressionBrotli}
+
+// Next returns the encoding that follows c, back to the raw sizes after brotli.
+func (c Compression) Next() Compression {
+	for i, compression := range Compressions {
+		if compression == c {
+			return Compressions[(i+1)%len(Compressions)]
+		}
+	}
+
+	return CompressionNone
 }
 
-type LintBenchmark struct {
-	ID          uuid.UUID             `json:"id"`
-	Project     string                `json:"project"`
-	Type        workspace.ProjectType `json:"type"`
-	CreatedAt   time.Time             `json:"createdAt"`
-	Duration    float64               `json:"duration"`
-	Description string                `json:"description"`
-	Min         float64               `json:"min"`
-	Max         float64               `json:"max"`
-	Average     float64               `json:"avg"`
-	TotalRuns   int                   `json:"totalRuns"`
+// StatsOf returns the stats in the given encoding. It reports false when the compressed sizes weren't recorded.
+func (b BundleBenchmark) StatsOf(compression Compression) (BuildStats, bool) {
+	switch compression {
+	case CompressionGzip:
+		if b.GzipStats == nil {
+			return BuildStats{}, false
+		}
+		return *b.GzipStats, true
+	case CompressionBrotli:
+		if b.BrotliStats == nil {
+			return BuildStats{}, false
+		}
+		return *b.BrotliStats, true
+	}
+
+	return b.Stats, true
+}
+
+// FileCategory is the part of the stats a file of the build output is counted in.
+type FileCategory string
+
+const (
+	FileMain      FileCategory = "main"
+	FileRuntime   FileCategory = "runtime"
+	FilePolyfills FileCategory = "polyfills"
+	FileLazy      FileCategory = "lazy"
+	FileStyles    FileCategory = "styles"
+	FileAssets    FileCategory = "assets"
+	// FileOther holds the files left out of the stats, e.g. source maps, index.html or the manifests.
+	FileOther FileCategory = "other"
+)
+
+// BundleFile is a file of the build output. NormalizedName is its path without the content hash,
+// e.g. chunk.js for chunk-5DZ7QKTA.js, so that the same file can be followed across builds.
+// The compressed sizes are only measured for the JavaScript and CSS files.
+type BundleFile struct {
+	Name           string       `json:"name"`
+	NormalizedName string       `json:"normalizedName"`
+	Category       FileCategory `json:"category"`
+	Size           int64        `json:"size"`
+	Gzip           int64        `json:"gzip,omitempty"`
+	Brotli         int64        `json:"brotli,omitempty"`
+}
+
+// BudgetStatus is the outcome of the check of a budget, from the best to the worst.
+type BudgetStatus string
+
+const (
+	BudgetOK      BudgetStatus = "ok"
+	BudgetWarning BudgetStatus = "warning"
+	BudgetError   BudgetStatus = "error"
+	// BudgetSkipped is set when the budget can't be checked against the output, e.g. a relative budget without a baseline.
+	BudgetSkipped BudgetStatus = "skipped"
+)
+
+// BudgetResult is the check of an Angular budget. The thresholds are in bytes, 0 when the budget doesn't set them.
+type BudgetResult struct {
+	Type string `json:"type"`
+	// Name is the bundle of the budgets of type bundle.
+	Name string `json:"name,omitempty"`
+	// Size is the size checked, the one of the worst file for the budgets checking each file, e.g. anyScript.
+	Size int64 `json:"size"`
+	// File is the worst file of the budgets checking each file.
+	File           string       `json:"file,omitempty"`
+	MaximumWarning int64        `json:"maximumWarning,omitempty"`
+	MaximumError   int64        `json:"maximumError,omitempty"`
+	MinimumWarning int64        `json:"minimumWarning,omitempty"`
+	MinimumError   int64        `json:"minimumError,omitempty"`
+	Status         BudgetStatus `json:"status"`
+	// Message explains a status the sizes don't tell, e.g. why the budget was skipped.
+	Message string `json:"message,omitempty"`
+}
+
+// Label names the budget the way the Angular configuration does, e.g. "bundle main" or "initial".
+func (b BudgetResult) Label() string {
+	if b.Name != "" {
+		return b.Type + " " + b.Name
+	}
+
+	return b.Type
+}
+
+// String describes the check, e.g. "initial: 614.45KB, over the maximum warning of 500KB".
+func (b BudgetResult) String() string {
+	switch {
+	case b.Status == BudgetSkipped:
+		return fmt.Sprintf("%s: skipped, %s", b.Label(), b.Message)
+	case b.Type == "anyComponentStyle" && b.Status == BudgetOK:
+		return fmt.Sprintf("%s: no component style over budget", b.Label())
+	case b.Type == "anyComponentStyle":
+		return fmt.Sprintf("%s: %s", b.Label(), b.Message)
+	}
+
+	size := utils.FormatFileSize(b.Size)
+	if b.File != "" {
+		size = fmt.Sprintf("%s (%s)", size, b.File)
+	}
+
+	switch b.Status {
+	case BudgetError:
+		return fmt.Sprintf("%s: %s, %s", b.Label(), size, exceeded(b.Size, b.MaximumError, b.MinimumError, "error"))
+	case BudgetWarning:
+		return fmt.Sprintf("%s: %s, %s", b.Label(), size, exceeded(b.Size, b.MaximumWarning, b.MinimumWarning, "warning"))
+	}
+
+	return fmt.Sprintf("%s: %s, within budget", b.Label(), size)
+}
+
+func exceeded(size, maximum, minimum int64, level string) string {
+	if maximum > 0 && size > maximum {
+		return fmt.Sprintf("over the maximum %s of %s", level, utils.FormatFileSize(maximum))
+	}
+
+	return fmt.Sprintf("under the minimum %s of %s", level, utils.FormatFileSize(minimum))
+}
+
+// BudgetStatus returns the worst status of the budgets, ok when there are none.
+func (b BundleBenchmark) BudgetStatus() BudgetStatus {
+	status := BudgetOK
+
+	for _, budget := range b.Budgets {
+		switch budget.Status {
+		case BudgetError:
+			return BudgetError
+		case BudgetWarning:
+			status = BudgetWarning
+		}
+	}
+
+	return status
+}
+
+// PackageSize is the bytes of the JavaScript output mapped back to the modules of an npm package, or of the workspace.
+type PackageSize struct {
+	Name    string       `json:"name"`
+	Size    int64        `json:"size"`
+	Modules []ModuleSize `json:"modules"`
+}
+
+// ModuleSize is the bytes of the JavaScript output mapped back to a source file, its path relative to the package.
+type ModuleSize struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// SizeOf returns the size of the file in the given encoding, the raw size for the files that aren't compressed.
+func (f BundleFile) SizeOf(compression Compression) int64 {
+	switch {
+	case compression == CompressionGzip && f.Gzip > 0:
+		return f.Gzip
+	case compression == CompressionBrotli && f.Brotli > 0:
+		return f.Brotli
+	}
+
+	return f.Size
+}
+
+// RunStatus is the outcome of a single execution of the benchmarked target.
+type RunStatus string
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+	RunCancelled RunStatus = "cancelled"
+)
+
+// Exclusion explains why a run is left out of the stats.
+type Exclusion string
+
+const (
+	ExcludedWarmup  Exclusion = "warm-up"
+	ExcludedOutlier Exclusion = "outlier"
+)
+
+// OutlierPolicy decides which of the succeeded runs are rejected as outliers before aggregating.
+type OutlierPolicy string
+
+const (
+	OutliersNone OutlierPolicy = "none"
+	// OutliersIQR rejects the durations outside of [Q1 - 1.5 IQR, Q3 + 1.5 IQR].
+	OutliersIQR OutlierPolicy = "iqr"
+	// OutliersMAD rejects the durations whose modified z-score, based on the median absolute deviation, exceeds 3.5.
+	OutliersMAD OutlierPolicy = "mad"
+)
+
+// CacheMode decides how the Nx cache is handled across the runs of a benchmark.
+type CacheMode string
+
+const (
+	// CacheCold resets the Nx cache before every run.
+	CacheCold CacheMode = "cold"
+	// CacheResetOnce resets the Nx cache once, before the first run.
+	CacheResetOnce CacheMode = "reset-once"
+	// CacheWarm never resets the Nx cache, so the runs may be served from the local or the remote cache.
+	CacheWarm CacheMode = "warm"
+	// CacheSkip passes --skip-nx-cache to every run, without resetting the Nx cache.
+	CacheSkip CacheMode = "skip-nx-cache"
+)
+
+// CacheModes lists the supported cache modes, the default one first.
+var CacheModes = []CacheMode{CacheCold, CacheResetOnce, CacheWarm, CacheSkip}
+
+// Cache describes the cache mode and the daemon setting a benchmark was recorded with.
+// The benchmarks recorded before the cache mode was configurable ran cold, without the daemon.
+type Cache struct {
+	CacheMode CacheMode `json:"cacheMode,omitempty"`
+	Daemon    bool      `json:"daemon,omitempty"`
+}
+
+func (c Cache) Mode() CacheMode {
+	if c.CacheMode == "" {
+		return CacheCold
+	}
+
+	return c.CacheMode
+}
+
+// String describes the cache setup, e.g. "warm, daemon on".
+func (c Cache) String() string {
+	return fmt.Sprintf("%s, daemon %s", c.Mode(), utils.Ternary(c.Daemon, "on", "off"))
+}
+
+// Short describes the cache setup in a compact form, e.g. "once +daemon".
+func (c Cache) Short() string {
+	mode := strings.TrimSuffix(strings.TrimSuffix(string(c.Mode()), "-nx-cache"), "reset-")
+
+	return mode + utils.Ternary(c.Daemon, " +daemon", "")
+}
+
+// TargetOptions describes how the benchmarked target is invoked, on top of its project.
+type TargetOptions struct {
+	// Configuration is passed as --configuration, the default configuration of the target being used when empty.
+	Configuration string `json:"configuration,omitempty"`
+	// Args are appended to the nx command, e.g. --coverage=false.
+	Args []string `json:"args,omitempty"`
+	// Env holds extra environment variables in the KEY=VALUE form.
+	Env []string `json:"env,omitempty"`
+}
+
+// String describes the options the way they are passed to nx, e.g. "--configuration=production --ci CI=true".
+func (o TargetOptions) String() string {
+	var parts []string
+
+	if o.Configuration != "" {
+		parts = append(parts, "--configuration="+o.Configuration)
+	}
+
+	parts = append(parts, o.Args...)
+	parts = append(parts, o.Env...)
+
+	return strings.Join(parts, " ")
+}
+
+// ParseEnv splits space separated KEY=VALUE pairs into environment variables.
+func ParseEnv(str string) ([]string, error) {
+	env := strings.Fields(str)
+
+	for _, variable := range env {
+		if name, _, ok := strings.Cut(variable, "="); !ok || name == "" {
+			return nil, fmt.Errorf("%s is not in the KEY=VALUE form", variable)
+		}
+	}
+
+	return env, nil
+}
+
+// SuiteRun tags the benchmarks recorded by a run of a suite, which share the suite run ID.
+// It is empty for the benchmarks started on their own.
+type SuiteRun struct {
+	Suite      string `json:"suite,omitempty"`
+	SuiteRunID string `json:"suiteRunId,omitempty"`
+}
+
+// String describes the suite run, e.g. "release, run 8b7f11d7", empty when the benchmark wasn't run by a suite.
+func (s SuiteRun) String() string {
+	if s.Suite == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s, run %s", s.Suite, s.SuiteRunID[:min(8, len(s.SuiteRunID))])
+}
+
+// Run describes a single execution of the benchmarked target.
+// Only the duration of a succeeded run is meaningful.
+type Run struct {
+	Status   RunStatus `json:"status,omitempty"`
+	Duration float64   `json:"duration"`
+	ExitCode int       `json:"exitCode,omitempty"`
+	Excluded Exclusion `json:"excluded,omitempty"`
+	Log      string    `json:"log,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	// Tasks are the Nx tasks executed by the run of a workspace benchmark.
+	Tasks []TaskRun `json:"tasks,omitempty"`
+}
+
+// TaskRun is an Nx task executed by nx run-many or nx affected, e.g. shell:build.
+// Its duration is 0 when it could only be found in the output of nx, which doesn't time the tasks.
+type TaskRun struct {
+	Task        string  `json:"task"`
+	Project     string  `json:"project"`
+	Target      string  `json:"target"`
+	Duration    float64 `json:"duration"`
+	CacheStatus string  `json:"cacheStatus,omitempty"`
+	Status      int     `json:"status"`
+}
+
+// Cached reports whether the outputs of the task were restored from the local or the remote cache.
+func (t TaskRun) Cached() bool {
+	return strings.Contains(t.CacheStatus, "hit") || strings.Contains(t.CacheStatus, "kept")
 }
 
+// Summary describes the distribution of the durations of the succeeded runs, in seconds.
+// CV is the coefficient of variation (StdDev / Average) and CILow-CIHigh the 95% confidence interval of the average.
+type Summary struct {
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Average float64 `json:"avg"`
+	Median  float64 `json:"median"`
+	P90     float64 `json:"p90"`
+	P95     float64 `json:"p95"`
+	StdDev  float64 `json:"stdDev"`
+	CV      float64 `json:"cv"`
+	CILow   float64 `json:"ciLow"`
+	CIHigh  float64 `json:"ciHigh"`
+}
+
+// AllRunsFailed reports whether none of the measured runs of a benchmark succeeded, its summary being empty.
+func AllRunsFailed(totalRuns, failedRuns int) bool {
+	return totalRuns > 0 && failedRuns == totalRuns
+}
+
+// BudgetMetric is the value of a benchmark limited by a performance budget of the gonx configuration,
+// a statistic of the durations or a size bucket of the bundle analyser.
+type BudgetMetric string
+
+const (
+	MetricMin     BudgetMetric = "min"
+	MetricMax     BudgetMetric = "max"
+	MetricAverage BudgetMetric = "avg"
+	MetricMedian  BudgetMetric = "median"
+	MetricP90     BudgetMetric = "p90"
+	MetricP95     BudgetMetric = "p95"
+
+	MetricMain      BudgetMetric = "main"
+	MetricRuntime   BudgetMetric = "runtime"
+	MetricPolyfills BudgetMetric = "polyfills"
+	MetricInitial   BudgetMetric = "initial"
+	MetricLazy      BudgetMetric = "lazy"
+	MetricStyles    BudgetMetric = "styles"
+	MetricAssets    BudgetMetric = "assets"
+	MetricTotal     BudgetMetric = "total"
+	MetricOverall   BudgetMetric = "overall"
+)
+
+var DurationMetrics = []BudgetMetric{MetricMin, MetricMax, MetricAverage, MetricMedian, MetricP90, MetricP95}
+
+var SizeMetrics = []BudgetMetric{
+	MetricMain,
+	MetricRuntime,
+	MetricPolyfills,
+	MetricInitial,
+	MetricLazy,
+	MetricStyles,
+	MetricAssets,
+	MetricTotal,
+	MetricOverall,
+}
+
+// IsSize tells whether the metric is a size bucket, in bytes, rather than a duration, in seconds.
+func (m BudgetMetric) IsSize() bool {
+	return slices.Contains(SizeMetrics, m)
+}
+
+// Metric returns the statistic of the durations limited by the metric.
+func (s Summary) Metric(metric BudgetMetric) (float64, bool) {
+	switch metric {
+	case MetricMin:
+		return s.Min, true
+	case MetricMax:
+		return s.Max, true
+	case MetricAverage:
+		return s.Average, true
+	case MetricMedian:
+		return s.Median, true
+	case MetricP90:
+		return s.P90, true
+	case MetricP95:
+		return s.P95, true
+	}
+
+	return 0, false
+}
+
+// Metric returns the size bucket limited by the metric.
+func (stats BuildStats) Metric(metric BudgetMetric) (int64, bool) {
+	switch metric {
+	case MetricMain:
+		return stats.Initial.Main, true
+	case MetricRuntime:
+		return stats.Initial.Runtime, true
+	case MetricPolyfills:
+		return stats.Initial.Polyfills, true
+	case MetricInitial:
+		return stats.Initial.Total, true
+	case MetricLazy:
+		return stats.Lazy, true
+	case MetricStyles:
+		return stats.Styles, true
+	case MetricAssets:
+		return stats.Assets, true
+	case MetricTotal:
+		return stats.Total, true
+	case MetricOverall:
+		return stats.OverallTotal, true
+	}
+
+	return 0, false
+}
+
+// BudgetCheck is the check of a performance budget of the gonx configuration against a benchmark.
+type BudgetCheck struct {
+	Metric BudgetMetric `json:"metric"`
+	// Projects are the patterns of the budget, empty when it applies to all the projects of the analyser.
+	Projects []string `json:"projects,omitempty"`
+	// Value and Limit are in seconds for the durations and in bytes for the sizes.
+	Value  float64 `json:"value"`
+	Limit  float64 `json:"limit"`
+	Passed bool    `json:"passed"`
+}
+
+// String describes the check, e.g. "avg: 95.20s, over the limit of 90.00s".
+func (c BudgetCheck) String() string {
+	format := func(value float64) string {
+		if c.Metric.IsSize() {
+			return utils.FormatFileSize(int64(value))
+		}
+
+		return fmt.Sprintf("%.2fs", value)
+	}
+
+	label := string(c.Metric)
+	if len(c.Projects) > 0 {
+		label = fmt.Sprintf("%s of %s", c.Metric, strings.Join(c.Projects, ", "))
+	}
+
+	if c.Passed {
+		return fmt.Sprintf("%s: %s, within the limit of %s", label, format(c.Value), format(c.Limit))
+	}
+
+	return fmt.Sprintf("%s: %s, over the limit of %s", label, format(c.Value), format(c.Limit))
+}
+
+// BudgetChecks are the performance budgets checked against a benchmark.
+type BudgetChecks []BudgetCheck
+
+// Passed tells whether the benchmark is within all its budgets, true when there are none.
+func (c BudgetChecks) Passed() bool {
+	return !slices.ContainsFunc(c, func(check BudgetCheck) bool { return !check.Passed })
+}
+
+// Label returns pass or fail, or - when no budget applies to the benchmark.
+func (c BudgetChecks) Label() string {
+	switch {
+	case len(c) == 0:
+		return "-"
+	case c.Passed():
+		return "pass"
+	}
+
+	return "fail"
+}
+
+// TestBenchmark times a target of a project: build, lint, test or any other Nx target, e.g. e2e or typecheck.
 type TestBenchmark struct {
 	ID          uuid.UUID             `json:"id"`
+	Target      string                `json:"target,omitempty"`
 	Project     string                `json:"project"`
 	Type        workspace.ProjectType `json:"type"`
 	CreatedAt   time.Time             `json:"createdAt"`
 	Duration    float64               `json:"duration"`
 	Description string                `json:"description"`
-	Min         float64               `json:"min"`
-	Max         float64               `json:"max"`
-	Average     float64               `json:"avg"`
-	TotalRuns   int                   `json:"totalRuns"`
+	Summary
+	Cache
+	TargetOptions
+	SuiteRun
+	// Workers is the number of projects benchmarked concurrently, which changes the timings through contention.
+	// It is 0 for the benchmarks recorded before the projects could run in parallel, which ran sequentially.
+	Workers       int           `json:"workers,omitempty"`
+	TotalRuns     int           `json:"totalRuns"`
+	FailedRuns    int           `json:"failedRuns"`
+	SuccessRate   float64       `json:"successRate"`
+	WarmupRuns    int           `json:"warmupRuns,omitempty"`
+	OutlierPolicy OutlierPolicy `json:"outlierPolicy,omitempty"`
+	Outliers      int           `json:"outliers,omitempty"`
+	Cancelled     bool          `json:"cancelled,omitempty"`
+	BudgetChecks  BudgetChecks  `json:"budgetChecks,omitempty"`
+	Runs          []Run         `json:"runs,omitempty"`
+}
+
+// UnmarshalJSON also reads the build benchmarks recorded before they were written as the benchmarks of the other targets,
+// whose app was written as appName.
+func (b *TestBenchmark) UnmarshalJSON(content []byte) error {
+	type benchmark TestBenchmark
+
+	record := struct {
+		*benchmark
+		AppName string `json:"appName"`
+	}{benchmark: (*benchmark)(b)}
+
+	if err := json.Unmarshal(content, &record); err != nil {
+		return err
+	}
+
+	if b.Project == "" && record.AppName != "" {
+		b.Project = record.AppName
+		b.Type = workspace.ApplicationType
+	}
+
+	return nil
+}
+
+// WorkspaceOperation is the nx command benchmarked as a whole by a workspace benchmark.
+type WorkspaceOperation string
+
+const (
+	OperationRunMany  WorkspaceOperation = "run-many"
+	OperationAffected WorkspaceOperation = "affected"
+)
+
+// TaskSummary aggregates the durations of an Nx task over the runs of a workspace benchmark.
+type TaskSummary struct {
+	Task    string `json:"task"`
+	Project string `json:"project"`
+	Target  string `json:"target"`
+	Summary
+	// Runs is the number of timed executions of the task and CacheHits how many of them were restored from the cache.
+	Runs      int `json:"runs"`
+	CacheHits int `json:"cacheHits"`
+}
+
+// WorkspaceBenchmark times nx run-many or nx affected as a single operation, the way CI runs it.
+type WorkspaceBenchmark struct {
+	ID        uuid.UUID          `json:"id"`
+	Operation WorkspaceOperation `json:"operation"`
+	Targets   []string           `json:"targets"`
+	// Projects are the projects passed to run-many, all the projects being used when empty.
+	Projects []string `json:"projects,omitempty"`
+	// Base is the git reference nx affected compares the working tree against.
+	Base        string    `json:"base,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Duration    float64   `json:"duration"`
+	Description string    `json:"description"`
+	Summary
+	Cache
+	TargetOptions
+	SuiteRun
+	// Parallel is the --parallel option of nx, 0 when the default of the workspace was used.
+	Parallel      int           `json:"parallel,omitempty"`
+	TotalRuns     int           `json:"totalRuns"`
+	FailedRuns    int           `json:"failedRuns"`
+	SuccessRate   float64       `json:"successRate"`
+	WarmupRuns    int           `json:"warmupRuns,omitempty"`
+	OutlierPolicy OutlierPolicy `json:"outlierPolicy,omitempty"`
+	Outliers      int           `json:"outliers,omitempty"`
+	Cancelled     bool          `json:"cancelled,omitempty"`
+	Tasks         []TaskSummary `json:"tasks,omitempty"`
+	// UntimedTasks is set when nx wrote no run summary, the tasks being only found in its output, without their durations.
+	UntimedTasks bool  `json:"untimedTasks,omitempty"`
+	Runs         []Run `json:"runs,omitempty"`
+}
+
+// Command returns the nx command of the benchmark, e.g. "run-many -t build,lint".
+func (b WorkspaceBenchmark) Command() string {
+	command := fmt.Sprintf("%s -t %s", b.Operation, strings.Join(b.Targets, ","))
+
+	if len(b.Projects) > 0 {
+		command += " -p " + strings.Join(b.Projects, ",")
+	}
+
+	if b.Base != "" {
+		command += " --base=" + b.Base
+	}
+
+	return command
 }
@@ -0,0 +1,42 @@
+package benchmark_data
+
+import (
+	"encoding/json"
+	"github.com/ionut-t/gonx/internal/constants"
+	"github.com/ionut-t/gonx/utils"
+	"os"
+)
+
+// Prepend writes the benchmark first in the history of the file, which is created when missing.
+func Prepend(file string, benchmark any) error {
+	record, err := utils.ToJsonString(benchmark)
+
+	if err != nil {
+		return err
+	}
+
+	var results []json.RawMessage
+	currentValue, err := os.ReadFile(file)
+
+	if err == nil && len(currentValue) > 0 {
+		if err := json.Unmarshal(currentValue, &results); err != nil {
+			return err
+		}
+	}
+
+	results = append([]json.RawMessage{json.RawMessage(record)}, results...)
+
+	content, err := json.MarshalIndent(results, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(constants.BenchmarkFolderPath, 0755)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, content, 0644)
+}
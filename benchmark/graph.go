@@ -0,0 +1,411 @@
+package benchmark
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"io"
+	"slices"
+	"strings"
+)
+
+// graphScope is the part of the project graph taken into an analyser along with the selected project.
+type graphScope int
+
+const (
+	// downstreamScope takes the projects depending on the selected project, directly or not.
+	downstreamScope graphScope = iota
+	// upstreamScope takes the projects the selected project depends on, directly or not.
+	upstreamScope
+)
+
+func (s graphScope) String() string {
+	if s == upstreamScope {
+		return "upstream"
+	}
+
+	return "downstream"
+}
+
+// graphSelectionMsg is sent once a set of projects of the graph is taken into an analyser.
+type graphSelectionMsg struct {
+	task     taskType
+	projects []string
+}
+
+// graphAnalysers are the tasks a set of projects of the graph can be benchmarked with.
+var graphAnalysers = []taskType{
+	bundleAnalyserTask,
+	buildAnalyserTask,
+	lintAnalyserTask,
+	testsAnalyserTask,
+	targetAnalyserTask,
+	workspaceAnalyserTask,
+}
+
+type projectGraphModel struct {
+	list      list.Model
+	workspace workspace.Model
+	graph     workspace.Graph
+	scope     graphScope
+	// analysers is shown once the set of projects is confirmed, to pick the analyser to open
+	analysers     *list.Model
+	width, height int
+}
+
+func (m projectGraphModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m projectGraphModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.analysers != nil {
+		return m.updateAnalysers(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.list.SetSize(m.listWidth(), m.listHeight())
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab":
+			m.scope = utils.Ternary(m.scope == downstreamScope, upstreamScope, downstreamScope)
+			return m, nil
+
+		case "enter":
+			if _, ok := m.list.SelectedItem().(graphItem); !ok {
+				return m, nil
+			}
+
+			analysers := newAnalysersList(m.workspace, m.selection(), m.width, m.height)
+			m.analysers = &analysers
+			return m, nil
+
+		case "esc", "backspace":
+			return m, messages.Dispatch(messages.NavigateToViewMsg(0))
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m projectGraphModel) updateAnalysers(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.list.SetSize(m.listWidth(), m.listHeight())
+		m.analysers.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			analyser, ok := m.analysers.SelectedItem().(analyserItem)
+			if !ok {
+				return m, nil
+			}
+
+			return m, messages.Dispatch(graphSelectionMsg{task: analyser.task, projects: m.selection()})
+
+		case "esc", "backspace":
+			m.analysers = nil
+			return m, nil
+		}
+	}
+
+	analysers, cmd := m.analysers.Update(msg)
+	m.analysers = &analysers
+
+	return m, cmd
+}
+
+func (m projectGraphModel) View() string {
+	if m.analysers != nil {
+		return "\n" + m.analysers.View()
+	}
+
+	if len(m.list.Items()) == 0 {
+		return "\n" + listTitleStyle.Render(styles.Warning.Render("No project was found in the workspace."))
+	}
+
+	// the help is rendered under both columns, being wider than the list
+	projects := lipgloss.NewStyle().Width(m.listWidth()).Render(m.list.View())
+
+	return "\n" + lipgloss.JoinHorizontal(lipgloss.Top, projects, m.details()) + "\n" + m.help()
+}
+
+func (m projectGraphModel) help() string {
+	help := m.list.Help
+	help.Width = m.width
+
+	return helpStyle.Render(help.ShortHelpView(m.list.ShortHelp()))
+}
+
+// selection returns the selected project along with the projects of the current scope.
+func (m projectGraphModel) selection() []string {
+	item, ok := m.list.SelectedItem().(graphItem)
+	if !ok {
+		return nil
+	}
+
+	related := utils.Ternary(m.scope == downstreamScope, item.dependents, item.dependencies)
+
+	return append([]string{item.project.GetName()}, related...)
+}
+
+// details renders the dependencies and the dependents of the selected project.
+func (m projectGraphModel) details() string {
+	item, ok := m.list.SelectedItem().(graphItem)
+	if !ok {
+		return ""
+	}
+
+	width := max(m.width-m.listWidth()-4, 20)
+	name := item.project.GetName()
+
+	header := styles.Primary.Bold(true).Render(name) + "\n" +
+		styles.DimText.Render(strings.Join(utils.Filter(
+			[]string{string(item.project.GetType()), item.project.GetRoot(), strings.Join(item.project.GetTags(), ", ")},
+			func(s string) bool { return s != "" },
+		), " · "))
+
+	dependencies := m.relatedProjects("Dependencies", m.graph.Dependencies(name), item.dependencies, width)
+	dependents := m.relatedProjects("Dependents", m.graph.Dependents(name), item.dependents, width)
+
+	selection := m.selection()
+	scope := styles.Accent.Render(fmt.Sprintf("%s selection · %d %s", utils.Ternary(m.scope == downstreamScope, "Downstream", "Upstream"), len(selection), utils.Ternary(len(selection) == 1, "project", "projects"))) +
+		"\n" + lipgloss.NewStyle().Width(width).Render(styles.NormalText.Render(strings.Join(selection, ", ")))
+
+	hint := styles.DimText.Render(fmt.Sprintf("tab switches to the %s projects", utils.Ternary(m.scope == downstreamScope, upstreamScope, downstreamScope)))
+
+	return lipgloss.NewStyle().
+		PaddingLeft(2).
+		PaddingTop(1).
+		MaxHeight(m.listHeight()).
+		Render(strings.Join([]string{header, dependencies, dependents, scope + "\n" + hint}, "\n\n"))
+}
+
+// relatedProjects renders the counts of the related projects, the direct ones being highlighted.
+func (m projectGraphModel) relatedProjects(title string, direct, transitive []string, width int) string {
+	counts := fmt.Sprintf("%s  %d direct · %d transitive", styles.Info.Render(title), len(direct), len(transitive))
+
+	if len(transitive) == 0 {
+		return counts + "\n" + styles.DimText.Render("none")
+	}
+
+	names := make([]string, len(transitive))
+	for i, name := range transitive {
+		names[i] = utils.Ternary(slices.Contains(direct, name), styles.NormalText.Render(name), styles.DimText.Render(name))
+	}
+
+	return counts + "\n" + lipgloss.NewStyle().Width(width).Render(strings.Join(names, styles.DimText.Render(", ")))
+}
+
+// listWidth leaves the rest of the width to the details of the selected project.
+func (m projectGraphModel) listWidth() int {
+	return min(max(m.width*2/5, 30), 60)
+}
+
+func (m projectGraphModel) listHeight() int {
+	return m.height - lipgloss.Height(m.help()) - 1
+}
+
+func newProjectGraph(ws workspace.Model, width, height int) projectGraphModel {
+	graph := ws.Graph()
+
+	var items []list.Item
+
+	for _, project := range ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType, workspace.E2EType}) {
+		items = append(items, graphItem{
+			project:      project,
+			dependencies: graph.TransitiveDependencies(project.GetName()),
+			dependents:   graph.TransitiveDependents(project.GetName()),
+		})
+	}
+
+	slices.SortFunc(items, func(a, b list.Item) int {
+		return strings.Compare(a.(graphItem).project.GetName(), b.(graphItem).project.GetName())
+	})
+
+	m := projectGraphModel{workspace: ws, graph: graph, width: width, height: height}
+
+	projectsList := list.New(items, graphItemDelegate{}, m.listWidth(), height)
+	projectsList.Title = "Project graph"
+	projectsList.SetShowStatusBar(false)
+	projectsList.SetShowHelp(false)
+	projectsList.SetFilteringEnabled(false)
+	projectsList.Styles.Title = listTitleStyle
+	projectsList.Help.Styles.ShortKey = styles.Subtext0
+	projectsList.Help.Styles.ShortDesc = styles.Overlay1
+	projectsList.Help.Styles.ShortSeparator = styles.Subtext0
+	projectsList.Styles.HelpStyle = helpStyle
+	projectsList.InfiniteScrolling = true
+
+	projectsList.KeyMap = list.KeyMap{
+		CursorUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		CursorDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl-q", "ctrl-c"),
+			key.WithHelp("ctrl-(q/c)", "quit"),
+		),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c")),
+	}
+
+	projectsList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("tab"),
+				key.WithHelp("tab", "upstream/downstream"),
+			),
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "benchmark"),
+			),
+			key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "back"),
+			),
+		}
+	}
+
+	m.list = projectsList
+	m.list.SetHeight(m.listHeight())
+
+	return m
+}
+
+type graphItem struct {
+	project workspace.Project
+	// dependencies and dependents are transitive
+	dependencies []string
+	dependents   []string
+}
+
+func (i graphItem) FilterValue() string { return "" }
+
+type graphItemDelegate struct{}
+
+func (d graphItemDelegate) Height() int                             { return 1 }
+func (d graphItemDelegate) Spacing() int                            { return 0 }
+func (d graphItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d graphItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(graphItem)
+	if !ok {
+		return
+	}
+
+	counts := styles.DimText.Render(fmt.Sprintf(" ↑%d ↓%d", len(i.dependencies), len(i.dependents)))
+
+	if index == m.Index() {
+		_, _ = fmt.Fprint(w, currentItemStyle.Render("> "+i.project.GetName())+counts)
+		return
+	}
+
+	_, _ = fmt.Fprint(w, itemStyle.Render(i.project.GetName())+counts)
+}
+
+func newAnalysersList(ws workspace.Model, selection []string, width, height int) list.Model {
+	var items []list.Item
+
+	for _, task := range graphAnalysers {
+		if task == targetAnalyserTask {
+			items = append(items, analyserItem{task: task, label: fmt.Sprintf("%s · for the projects defining the target", tasks[task])})
+			continue
+		}
+
+		eligible := utils.Filter(task.projects(ws), func(project workspace.Project) bool {
+			return slices.Contains(selection, project.GetName())
+		})
+
+		// the analysers that cannot benchmark any project of the selection are left out
+		if len(eligible) == 0 {
+			continue
+		}
+
+		items = append(items, analyserItem{task: task, label: fmt.Sprintf("%s · %d of %d projects", tasks[task], len(eligible), len(selection))})
+	}
+
+	analysersList := list.New(items, analyserItemDelegate{}, width, height)
+	analysersList.Title = "Benchmark the selection with"
+	analysersList.SetShowStatusBar(false)
+	analysersList.SetFilteringEnabled(false)
+	analysersList.Styles.Title = listTitleStyle
+	analysersList.Help.Styles.ShortKey = styles.Subtext0
+	analysersList.Help.Styles.ShortDesc = styles.Overlay1
+	analysersList.Help.Styles.ShortSeparator = styles.Subtext0
+	analysersList.Styles.HelpStyle = helpStyle
+	analysersList.InfiniteScrolling = true
+
+	analysersList.KeyMap = list.KeyMap{
+		CursorUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		CursorDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl-q", "ctrl-c"),
+			key.WithHelp("ctrl-(q/c)", "quit"),
+		),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c")),
+	}
+
+	analysersList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "confirm"),
+			),
+			key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "back"),
+			),
+		}
+	}
+
+	return analysersList
+}
+
+type analyserItem struct {
+	task  taskType
+	label string
+}
+
+func (i analyserItem) FilterValue() string { return "" }
+
+type analyserItemDelegate struct{}
+
+func (d analyserItemDelegate) Height() int                             { return 1 }
+func (d analyserItemDelegate) Spacing() int                            { return 0 }
+func (d analyserItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d analyserItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(analyserItem)
+	if !ok {
+		return
+	}
+
+	if index == m.Index() {
+		_, _ = fmt.Fprint(w, currentItemStyle.Render("> "+i.label))
+		return
+	}
+
+	_, _ = fmt.Fprint(w, itemStyle.Render(i.label))
+}
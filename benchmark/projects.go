@@ -14,12 +14,30 @@ import (
 	"strings"
 )
 
-type projectsSelectedMsg []workspace.Project
+type projectsSelectedMsg struct {
+	projects []workspace.Project
+	// configuration is empty when the default configuration of the target is used.
+	configuration string
+}
+
+// defaultConfiguration is the configuration item that runs the target without --configuration.
+const defaultConfiguration = "default"
 
 type selectProjectsModel struct {
-	list        list.Model
-	projects    []workspace.Project
-	displayType bool
+	list     list.Model
+	projects []workspace.Project
+	// selected holds the names of the selected projects, shared with the delegate of the list
+	selected       map[string]bool
+	displayType    bool
+	target         string
+	configurations *list.Model
+	// picker lists the tags, the types or the presets to select projects by
+	picker *selectionPicker
+	// prompt asks for a glob or for the name of a preset
+	prompt *selectionPrompt
+	// status reports the outcome of the last bulk selection, e.g. a saved preset
+	status        string
+	width, height int
 }
 
 func (m selectProjectsModel) Init() tea.Cmd {
@@ -27,53 +45,91 @@ func (m selectProjectsModel) Init() tea.Cmd {
 }
 
 func (m selectProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.configurations != nil {
+		return m.updateConfigurations(msg)
+	}
+
+	if m.picker != nil {
+		return m.updatePicker(msg)
+	}
+
+	if m.prompt != nil {
+		return m.updatePrompt(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
 		m.list.SetWidth(msg.Width)
 		return m, nil
 
 	case tea.KeyMsg:
+		// the keys are typed into the filter while it is being edited
+		if m.list.SettingFilter() {
+			break
+		}
+
+		m.status = ""
+
 		switch keypress := msg.String(); keypress {
 		case " ":
-			item, ok := m.list.SelectedItem().(selectItem)
-
-			if ok {
-				selectedIndex := m.list.Index()
-				selected := slices.Contains(m.getProjectNames(), item.item.GetName())
-
-				if selected {
-					var newItems []workspace.Project
-
-					for _, i := range m.projects {
-						if i.GetName() != item.item.GetName() {
-							newItems = append(newItems, i)
-						}
-					}
-
-					item.selected = false
-					m.projects = newItems
-				} else {
-					m.projects = append(m.projects, item.item)
-					item.selected = true
-				}
-
-				items := m.list.Items()
-				items[selectedIndex] = item
-				m.list.SetItems(items)
+			if item, ok := m.list.SelectedItem().(selectItem); ok {
+				m.toggle(item.item.GetName())
+			}
+
+			return m, nil
+
+		case "a", "n", "i":
+			m.selectVisible(keypress)
+			return m, nil
+
+		case "t":
+			m.openPicker(tagPicker)
+			return m, nil
+
+		case "y":
+			m.openPicker(typePicker)
+			return m, nil
+
+		case "p":
+			m.openPicker(presetPicker)
+			return m, nil
+
+		case "g":
+			m.prompt = newSelectionPrompt(globPrompt)
+			return m, nil
+
+		case "s":
+			if len(m.selectedProjects()) == 0 {
+				m.status = styles.Warning.Render("Select the projects of the preset first.")
+				return m, nil
 			}
 
+			m.prompt = newSelectionPrompt(presetPrompt)
 			return m, nil
 
 		case "enter":
-			if len(m.projects) == 0 {
+			projects := m.selectedProjects()
+			if len(projects) == 0 {
 				return m, nil
 			}
 
-			return m, func() tea.Msg {
-				return projectsSelectedMsg(m.projects)
+			configurations := commonConfigurations(projects, m.target)
+			if len(configurations) == 0 {
+				return m, messages.Dispatch(projectsSelectedMsg{projects: projects})
 			}
 
+			configurationsList := newConfigurationsList(configurations, m.width, m.height)
+			m.configurations = &configurationsList
+
+			return m, nil
+
 		case "esc", "backspace":
+			// esc clears the filter before leaving the selection
+			if m.list.FilterState() != list.Unfiltered {
+				break
+			}
+
 			return m, messages.Dispatch(messages.NavigateToViewMsg(0))
 		}
 	}
@@ -84,35 +140,212 @@ func (m selectProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m selectProjectsModel) View() string {
-	return "\n" + m.list.View()
+	if m.configurations != nil {
+		return "\n" + m.configurations.View()
+	}
+
+	if m.picker != nil {
+		return "\n" + m.picker.list.View()
+	}
+
+	if m.prompt != nil {
+		return "\n" + listTitleStyle.Render(m.prompt.input.View())
+	}
+
+	view := "\n" + m.list.View()
+
+	if m.status != "" {
+		view += "\n" + listTitleStyle.Render(m.status)
+	}
+
+	return view
+}
+
+// selectedProjects returns the selected projects in the order of the list.
+func (m selectProjectsModel) selectedProjects() []workspace.Project {
+	return utils.Filter(m.projects, func(project workspace.Project) bool {
+		return m.selected[project.GetName()]
+	})
+}
+
+func (m *selectProjectsModel) toggle(name string) {
+	if m.selected[name] {
+		delete(m.selected, name)
+	} else {
+		m.selected[name] = true
+	}
+
+	m.updateTitle()
+}
+
+// updateTitle shows how many projects are selected.
+func (m *selectProjectsModel) updateTitle() {
+	m.list.Title = selectProjectsTitle
+
+	if count := len(m.selectedProjects()); count > 0 {
+		m.list.Title += fmt.Sprintf(" · %d selected", count)
+	}
+}
+
+func (m selectProjectsModel) updateConfigurations(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.list.SetWidth(msg.Width)
+		m.configurations.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			configuration, ok := m.configurations.SelectedItem().(configurationItem)
+			if !ok {
+				return m, nil
+			}
+
+			return m, messages.Dispatch(projectsSelectedMsg{
+				projects:      m.selectedProjects(),
+				configuration: utils.Ternary(string(configuration) == defaultConfiguration, "", string(configuration)),
+			})
+
+		case "esc", "backspace":
+			m.configurations = nil
+			return m, nil
+		}
+	}
+
+	configurations, cmd := m.configurations.Update(msg)
+	m.configurations = &configurations
+
+	return m, cmd
+}
+
+// commonConfigurations returns the configurations of the target shared by all the projects.
+func commonConfigurations(projects []workspace.Project, target string) []string {
+	configurations := projects[0].GetConfigurations(target)
+
+	for _, project := range projects[1:] {
+		configurations = utils.Filter(configurations, func(name string) bool {
+			return slices.Contains(project.GetConfigurations(target), name)
+		})
+	}
+
+	return configurations
+}
+
+func newConfigurationsList(configurations []string, width, height int) list.Model {
+	items := []list.Item{configurationItem(defaultConfiguration)}
+	for _, configuration := range configurations {
+		items = append(items, configurationItem(configuration))
+	}
+
+	configurationsList := list.New(items, configurationItemDelegate{}, width, height)
+	configurationsList.Title = "Select the configuration of the target"
+	configurationsList.SetShowStatusBar(false)
+	configurationsList.SetFilteringEnabled(false)
+	configurationsList.Styles.Title = listTitleStyle
+	configurationsList.Help.Styles.ShortKey = styles.Subtext0
+	configurationsList.Help.Styles.ShortDesc = styles.Overlay1
+	configurationsList.Help.Styles.ShortSeparator = styles.Subtext0
+	configurationsList.Styles.HelpStyle = helpStyle
+	configurationsList.InfiniteScrolling = true
+
+	configurationsList.KeyMap = list.KeyMap{
+		CursorUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		CursorDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl-q", "ctrl-c"),
+			key.WithHelp("ctrl-(q/c)", "quit"),
+		),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c")),
+	}
+
+	configurationsList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "confirm"),
+			),
+			key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "back"),
+			),
+		}
+	}
+
+	return configurationsList
+}
+
+type configurationItem string
+
+func (i configurationItem) FilterValue() string { return "" }
+
+type configurationItemDelegate struct{}
+
+func (d configurationItemDelegate) Height() int                             { return 1 }
+func (d configurationItemDelegate) Spacing() int                            { return 0 }
+func (d configurationItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d configurationItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(configurationItem)
+	if !ok {
+		return
+	}
+
+	if index == m.Index() {
+		_, _ = fmt.Fprint(w, currentItemStyle.Render("> "+string(i)))
+		return
+	}
+
+	_, _ = fmt.Fprint(w, itemStyle.Render(string(i)))
 }
 
 type projectsListOptions struct {
 	projects      []workspace.Project
 	width, height int
 	displayType   bool
+	// target is the Nx target of the benchmark, whose configurations can be picked once the projects are selected.
+	target string
+	// selected are the names of the projects selected from the start, e.g. from the project graph.
+	selected []string
 }
 
+const selectProjectsTitle = "Select one or more projects"
+
 func newSelectionList(options projectsListOptions) selectProjectsModel {
 	var listItems []list.Item
+	selected := make(map[string]bool)
 
 	for _, item := range options.projects {
-		listItems = append(listItems, selectItem{item: item, selected: false})
+		if slices.Contains(options.selected, item.GetName()) {
+			selected[item.GetName()] = true
+		}
+
+		listItems = append(listItems, selectItem{item: item})
 	}
 
 	const defaultWidth = 20
 
 	itemsList := list.New(listItems, selectItemDelegate{
 		displayType: options.displayType,
+		selected:    selected,
 	}, defaultWidth, options.height)
-	itemsList.Title = "Select one or more projects"
 	itemsList.SetShowStatusBar(false)
-	itemsList.SetFilteringEnabled(false)
 	itemsList.Styles.Title = listTitleStyle
 	itemsList.Help.Styles.ShortKey = styles.Subtext0
 	itemsList.Help.Styles.ShortDesc = styles.Overlay1
 	itemsList.Help.Styles.ShortSeparator = styles.Subtext0
+	itemsList.Help.Styles.FullKey = styles.Subtext0
+	itemsList.Help.Styles.FullDesc = styles.Overlay1
+	itemsList.Help.Styles.FullSeparator = styles.Subtext0
 	itemsList.Styles.HelpStyle = helpStyle
+	itemsList.FilterInput.PromptStyle = styles.Accent
+	itemsList.FilterInput.Cursor.Style = styles.Accent
 
 	itemsList.SetWidth(options.width)
 	itemsList.InfiniteScrolling = true
@@ -127,6 +360,31 @@ func newSelectionList(options projectsListOptions) selectProjectsModel {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "down"),
 		),
+		// Filtering.
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+		CancelWhileFiltering: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+		AcceptWhileFiltering: key.NewBinding(
+			key.WithKeys("enter", "up", "down"),
+			key.WithHelp("enter", "apply filter"),
+		),
+		ShowFullHelp: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "more"),
+		),
+		CloseFullHelp: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "close help"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("ctrl-q", "ctrl-c"),
 			key.WithHelp("ctrl-(q/c)", "quit"),
@@ -151,22 +409,37 @@ func newSelectionList(options projectsListOptions) selectProjectsModel {
 		}
 	}
 
-	return selectProjectsModel{
+	itemsList.AdditionalFullHelpKeys = func() []key.Binding {
+		return bulkSelectionKeys
+	}
+
+	m := selectProjectsModel{
 		list:        itemsList,
-		projects:    make([]workspace.Project, 0),
+		projects:    options.projects,
+		selected:    selected,
 		displayType: options.displayType,
+		target:      options.target,
+		width:       options.width,
+		height:      options.height,
 	}
+
+	m.updateTitle()
+
+	return m
 }
 
 type selectItem struct {
-	item     workspace.Project
-	selected bool
+	item workspace.Project
 }
 
-func (i selectItem) FilterValue() string { return "" }
+// FilterValue lets the projects be filtered by name, folder or tag.
+func (i selectItem) FilterValue() string {
+	return strings.Join(append([]string{i.item.GetName(), i.item.GetRoot()}, i.item.GetTags()...), " ")
+}
 
 type selectItemDelegate struct {
 	displayType bool
+	selected    map[string]bool
 }
 
 func (d selectItemDelegate) Height() int                             { return 1 }
@@ -185,7 +458,7 @@ func (d selectItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 
 	projectType := utils.Ternary(d.displayType, " ("+string(i.item.GetType())+")", "")
 
-	if i.selected {
+	if d.selected[i.item.GetName()] {
 		fn = func(s ...string) string {
 			return selectedItemStyle.PaddingLeft(4).Render("(*) " + strings.Join(s, " ") + projectType)
 		}
@@ -197,7 +470,7 @@ func (d selectItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 
 	if index == m.Index() {
 		fn = func(s ...string) string {
-			if i.selected {
+			if d.selected[i.item.GetName()] {
 				return selectedItemStyle.Render("> (*) " + strings.Join(s, " ") + projectType)
 			}
 
@@ -211,13 +484,3 @@ func (d selectItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 		return
 	}
 }
-
-func (m selectProjectsModel) getProjectNames() []string {
-	var names []string
-
-	for _, item := range m.projects {
-		names = append(names, item.GetName())
-	}
-
-	return names
-}
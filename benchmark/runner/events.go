@@ -0,0 +1,115 @@
+package runner
+
+import (
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/workspace"
+	"time"
+)
+
+// Event is sent on the stream returned by Run while a benchmark is in progress.
+// The events about a project carry the worker benchmarking it, numbered from 1.
+type Event interface {
+	event()
+}
+
+// ResetStarted is sent before the Nx cache is reset.
+type ResetStarted struct {
+	Time time.Time
+}
+
+// ResetFinished is sent once the Nx cache reset is over. When the reset
+// fails, the benchmark is aborted: no other project is started and the
+// stream is closed once the projects in progress are over.
+type ResetFinished struct {
+	Time  time.Time
+	Error error
+}
+
+// RunStarted is sent before the target is executed for a project.
+// The warm-up runs and the measured runs are numbered separately.
+type RunStarted struct {
+	Project    workspace.Project
+	Worker     int
+	StartTime  time.Time
+	CurrentRun int
+	TotalRuns  int
+	Warmup     bool
+}
+
+// RunFinished is sent after the target was executed for a project.
+// Duration is only set for a succeeded run, ExitCode for a failed one.
+type RunFinished struct {
+	Project    workspace.Project
+	Worker     int
+	EndTime    time.Time
+	CurrentRun int
+	TotalRuns  int
+	Warmup     bool
+	Status     data.RunStatus
+	Duration   float64
+	ExitCode   int
+	Error      error
+	Log        string
+}
+
+// Output is sent with the lines written by the nx process while the target is executed,
+// the lines written while the consumer was busy being sent together.
+type Output struct {
+	Project workspace.Project
+	Worker  int
+	Lines   []string
+}
+
+// StatsStarted is sent once all the runs of a project are over, before its stats are written.
+type StatsStarted struct {
+	Project   workspace.Project
+	Worker    int
+	StartTime time.Time
+}
+
+// StatsWritten is sent after the benchmark of a project was aggregated and persisted.
+type StatsWritten[T any] struct {
+	Project   workspace.Project
+	Worker    int
+	Time      time.Time
+	Benchmark T
+	Error     error
+}
+
+// Written is implemented by StatsWritten whatever its benchmark, for the consumers of the events of different benchmarks.
+type Written interface {
+	Event
+	Err() error
+}
+
+// Err returns the error of the aggregation or the persistence of the benchmark.
+func (e StatsWritten[T]) Err() error {
+	return e.Error
+}
+
+// Label numbers the run, e.g. "2/5" or "warm-up 1/2".
+func (e RunStarted) Label() string {
+	return runLabel(e.CurrentRun, e.TotalRuns, e.Warmup)
+}
+
+// Label numbers the run, e.g. "2/5" or "warm-up 1/2".
+func (e RunFinished) Label() string {
+	return runLabel(e.CurrentRun, e.TotalRuns, e.Warmup)
+}
+
+func runLabel(current, total int, warmup bool) string {
+	if warmup {
+		return fmt.Sprintf("warm-up %d/%d", current, total)
+	}
+
+	return fmt.Sprintf("%d/%d", current, total)
+}
+
+func (ResetStarted) event()    {}
+func (ResetFinished) event()   {}
+func (RunStarted) event()      {}
+func (RunFinished) event()     {}
+func (Output) event()          {}
+func (StatsStarted) event()    {}
+func (StatsWritten[T]) event() {}
@@ -0,0 +1,22 @@
+package runner
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ionut-t/gonx/internal/constants"
+	"os"
+	"path/filepath"
+)
+
+// logPath returns the path of the log file of a run, e.g. .gonx/logs/<benchmark-id>/run-1.log
+func logPath(id uuid.UUID, run int) string {
+	return filepath.Join(constants.LogsFolderPath, id.String(), fmt.Sprintf("run-%d.log", run))
+}
+
+func createLog(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	return os.Create(path)
+}
@@ -0,0 +1,152 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"github.com/ionut-t/gonx/workspace"
+	"sync"
+	"time"
+)
+
+// outputInterval is how often the lines written while the consumer was busy are sent again.
+const outputInterval = 100 * time.Millisecond
+
+// outputLimit is the number of lines kept while the consumer is busy, the oldest ones being dropped.
+const outputLimit = 500
+
+// lineWriter sends the lines written by the nx process of a project as Output events.
+// Carriage returns also end a line, so that progress bars redrawn in place are streamed too.
+// The process is never blocked by the consumer: the lines are batched until the consumer is ready
+// to receive them, so that a slow consumer doesn't add to the timed duration of the run.
+type lineWriter struct {
+	ctx     context.Context
+	project workspace.Project
+	worker  int
+	events  chan<- Event
+	buf     []byte
+
+	mu      sync.Mutex
+	pending []string
+	done    chan struct{}
+	stopped sync.WaitGroup
+}
+
+func newLineWriter(ctx context.Context, project workspace.Project, worker int, events chan<- Event) *lineWriter {
+	w := &lineWriter{ctx: ctx, project: project, worker: worker, events: events, done: make(chan struct{})}
+
+	// the lines left pending when the process goes quiet are sent on a tick
+	w.stopped.Add(1)
+	go func() {
+		defer w.stopped.Done()
+
+		ticker := time.NewTicker(outputInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.trySend()
+			case <-w.done:
+				return
+			}
+		}
+	}()
+
+	return w
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	var lines []string
+
+	for {
+		i := bytes.IndexAny(w.buf, "\r\n")
+		if i == -1 {
+			break
+		}
+
+		end := i + 1
+		if w.buf[i] == '\r' {
+			// wait for the next write to know whether the line ends with \r\n
+			if end == len(w.buf) {
+				break
+			}
+			if w.buf[end] == '\n' {
+				end++
+			}
+		}
+
+		lines = append(lines, string(w.buf[:i]))
+		w.buf = w.buf[end:]
+	}
+
+	if len(lines) > 0 {
+		w.push(lines...)
+		w.trySend()
+	}
+
+	return len(p), nil
+}
+
+// Flush sends the pending lines, along with the last line when the output doesn't end with a line break.
+// It waits for the consumer, the process being over.
+func (w *lineWriter) Flush() {
+	close(w.done)
+	w.stopped.Wait()
+
+	if line := string(bytes.TrimRight(w.buf, "\r")); line != "" {
+		w.push(line)
+	}
+	w.buf = nil
+
+	if output, ok := w.take(); ok {
+		// the lines written after a cancellation are dropped, so that the runner is never blocked
+		// by a consumer that stopped listening
+		select {
+		case w.events <- output:
+		case <-w.ctx.Done():
+		}
+	}
+}
+
+func (w *lineWriter) push(lines ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, lines...)
+	if len(w.pending) > outputLimit {
+		w.pending = w.pending[len(w.pending)-outputLimit:]
+	}
+}
+
+// trySend sends the pending lines as a single event when the consumer is ready to receive it,
+// and keeps them for the next attempt otherwise.
+func (w *lineWriter) trySend() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) == 0 {
+		return
+	}
+
+	select {
+	case w.events <- Output{Project: w.project, Worker: w.worker, Lines: w.pending}:
+		w.pending = nil
+	default:
+	}
+}
+
+func (w *lineWriter) take() (Output, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) == 0 {
+		return Output{}, false
+	}
+
+	output := Output{Project: w.project, Worker: w.worker, Lines: w.pending}
+	w.pending = nil
+
+	return output, true
+}
@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// waitDelay bounds how long a killed process may keep its output pipes open.
+const waitDelay = 5 * time.Second
+
+// processes tracks the nx processes started by all the benchmarks.
+var processes sync.WaitGroup
+
+func command(ctx context.Context, env []string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "nx", args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.WaitDelay = waitDelay
+	killProcessTree(cmd)
+
+	return cmd
+}
+
+func run(cmd *exec.Cmd) error {
+	processes.Add(1)
+	defer processes.Done()
+
+	return cmd.Run()
+}
+
+// Wait blocks until every nx process started by a benchmark has exited.
+// It is meant to be called after cancelling the benchmarks, so that no
+// process outlives gonx.
+func Wait() {
+	processes.Wait()
+}
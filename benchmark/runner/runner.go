@@ -0,0 +1,440 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/stats"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"io"
+	"os/exec"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrCancelled is reported for the run or the reset interrupted by a cancellation.
+var ErrCancelled = errors.New("benchmark cancelled")
+
+// Settings holds the benchmark settings chosen by the user.
+type Settings struct {
+	Description string
+	// Runs is the number of times the target is executed for every project.
+	Runs int
+	// KeepPartial controls whether the runs completed before a cancellation are persisted.
+	KeepPartial bool
+	// WarmupRuns are executed before the measured runs and left out of the stats.
+	WarmupRuns int
+	// Outliers is the policy applied to the measured runs once they are all completed.
+	Outliers data.OutlierPolicy
+	// Cache decides when the Nx cache is reset and whether the Nx daemon is used.
+	Cache data.Cache
+	// TargetOptions holds the configuration, the extra arguments and the environment variables of the target.
+	TargetOptions data.TargetOptions
+	// Workers is the number of projects benchmarked concurrently, the projects being benchmarked
+	// sequentially when it is 0 or 1. The runs of a project are always sequential.
+	Workers int
+	// Suite tags the benchmarks when they are recorded by a run of a suite.
+	Suite data.SuiteRun
+}
+
+// env returns the environment variables of the nx processes, the ones chosen by the user last.
+func (s Settings) env() []string {
+	var env []string
+
+	if !s.Cache.Daemon {
+		env = append(env, "NX_DAEMON=false")
+	}
+
+	return append(env, s.TargetOptions.Env...)
+}
+
+// args returns the arguments of the nx command, e.g. "build shell", followed by the options of the settings.
+func (s Settings) args(command []string) []string {
+	args := slices.Clone(command)
+
+	if s.TargetOptions.Configuration != "" {
+		args = append(args, "--configuration="+s.TargetOptions.Configuration)
+	}
+
+	if s.Cache.Mode() == data.CacheSkip {
+		args = append(args, "--skip-nx-cache")
+	}
+
+	return append(args, s.TargetOptions.Args...)
+}
+
+// Options describes how a benchmark is run.
+type Options[T any] struct {
+	Settings
+	// Projects are started in the given order, by as many workers as the settings allow.
+	Projects []workspace.Project
+	// Target is the Nx target executed for every project, e.g. build or lint.
+	Target string
+	// Command returns the arguments of the nx command run for project, "<target> <project>" when nil.
+	Command func(project workspace.Project) []string
+	// Tasks extracts the Nx tasks executed by a completed run from the run summary of nx or from the log of the run.
+	Tasks func(log string, startTime time.Time) []data.TaskRun
+	// Finish aggregates the runs of a project and persists its benchmark.
+	// It is called once at least one measured run completed, even when all of them failed, and never concurrently.
+	Finish func(result Result) (T, error)
+	// SuccessRequired only finishes the projects with a succeeded run, e.g. when the benchmark measures the output of the target.
+	SuccessRequired bool
+}
+
+// Run is the outcome of a single execution of the target.
+type Run struct {
+	Status   data.RunStatus
+	Duration float64
+	// ExitCode is the exit code of the nx process of a failed run, -1 when it couldn't be started.
+	ExitCode int
+	// Excluded is set for the warm-up runs and the outliers, left out of the stats.
+	Excluded data.Exclusion
+	Error    error
+	// Log is the path of the file holding the combined output of the run.
+	Log string
+	// Tasks are the Nx tasks executed by the run, only collected when Options.Tasks is set.
+	Tasks []data.TaskRun
+}
+
+// Result holds all the runs of a project.
+type Result struct {
+	// ID identifies the benchmark of the project; the logs of its runs are stored under it.
+	ID        uuid.UUID
+	Project   workspace.Project
+	StartTime time.Time
+	Runs      []Run
+	// Cancelled reports whether the benchmark was cancelled before all the runs were completed.
+	Cancelled bool
+}
+
+// Durations returns the duration of every succeeded run included in the stats.
+func (r Result) Durations() []float64 {
+	durations := make([]float64, 0, len(r.Runs))
+
+	for _, run := range r.Runs {
+		if run.Status == data.RunSucceeded && run.Excluded == "" {
+			durations = append(durations, run.Duration)
+		}
+	}
+
+	return durations
+}
+
+// TotalRuns returns the number of measured runs that were completed, successfully or not.
+func (r Result) TotalRuns() int {
+	return r.count(data.RunSucceeded) + r.count(data.RunFailed)
+}
+
+func (r Result) FailedRuns() int {
+	return r.count(data.RunFailed)
+}
+
+// SuccessRate returns the share of the completed runs that succeeded, between 0 and 1.
+func (r Result) SuccessRate() float64 {
+	if r.TotalRuns() == 0 {
+		return 0
+	}
+
+	return float64(r.count(data.RunSucceeded)) / float64(r.TotalRuns())
+}
+
+// Outliers returns the number of succeeded runs rejected by the outlier policy.
+func (r Result) Outliers() int {
+	count := 0
+
+	for _, run := range r.Runs {
+		if run.Excluded == data.ExcludedOutlier {
+			count++
+		}
+	}
+
+	return count
+}
+
+// count returns the number of measured runs with the given status, the warm-up runs being ignored.
+func (r Result) count(status data.RunStatus) int {
+	count := 0
+
+	for _, run := range r.Runs {
+		if run.Status == status && run.Excluded != data.ExcludedWarmup {
+			count++
+		}
+	}
+
+	return count
+}
+
+// rejectOutliers flags the succeeded measured runs rejected by the policy.
+func (r *Result) rejectOutliers(policy data.OutlierPolicy) {
+	var (
+		indexes   []int
+		durations []float64
+	)
+
+	for i, run := range r.Runs {
+		if run.Status == data.RunSucceeded && run.Excluded == "" {
+			indexes = append(indexes, i)
+			durations = append(durations, run.Duration)
+		}
+	}
+
+	for _, outlier := range stats.Outliers(durations, policy) {
+		r.Runs[indexes[outlier]].Excluded = data.ExcludedOutlier
+	}
+}
+
+// Records returns the runs in the form they are persisted in the benchmark history.
+func (r Result) Records() []data.Run {
+	records := make([]data.Run, len(r.Runs))
+
+	for i, run := range r.Runs {
+		records[i] = data.Run{
+			Status:   run.Status,
+			Duration: run.Duration,
+			ExitCode: run.ExitCode,
+			Excluded: run.Excluded,
+			Log:      run.Log,
+			Tasks:    run.Tasks,
+		}
+		if run.Error != nil {
+			records[i].Error = run.Error.Error()
+		}
+	}
+
+	return records
+}
+
+// Succeeded reports whether at least one run succeeded.
+func (r Result) Succeeded() bool {
+	return r.count(data.RunSucceeded) > 0
+}
+
+// Start runs the benchmark described by options in a separate goroutine and
+// returns its event stream. The stream is closed once the benchmark is over.
+//
+// Cancelling ctx kills the running nx process, along with its children, and
+// skips the remaining runs.
+func Start[T any](ctx context.Context, options Options[T]) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		execute(ctx, options, events)
+	}()
+
+	return events
+}
+
+func execute[T any](ctx context.Context, options Options[T], events chan<- Event) {
+	env := options.env()
+
+	if options.Cache.Mode() == data.CacheResetOnce && !reset(ctx, env, events) {
+		return
+	}
+
+	var (
+		// the runs hold the cache for reading and the resets for writing, so that a
+		// reset never happens while the target is executed for another project
+		cache sync.RWMutex
+		// the benchmarks of all the projects are persisted to the same file
+		finish   sync.Mutex
+		projects = make(chan workspace.Project)
+		aborted  = make(chan struct{})
+		abort    sync.Once
+		workers  sync.WaitGroup
+	)
+
+	for worker := 1; worker <= max(1, options.Workers); worker++ {
+		workers.Add(1)
+
+		go func() {
+			defer workers.Done()
+
+			for project := range projects {
+				if !benchmarkProject(ctx, options, project, worker, env, &cache, &finish, events) {
+					abort.Do(func() { close(aborted) })
+				}
+			}
+		}()
+	}
+
+	// no project is started once the benchmark is cancelled or aborted
+feed:
+	for _, project := range options.Projects {
+		select {
+		case projects <- project:
+		case <-aborted:
+			break feed
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(projects)
+	workers.Wait()
+}
+
+// benchmarkProject executes all the runs of project and persists its benchmark.
+// It reports whether the remaining projects should be benchmarked.
+func benchmarkProject[T any](
+	ctx context.Context,
+	options Options[T],
+	project workspace.Project,
+	worker int,
+	env []string,
+	cache *sync.RWMutex,
+	finish *sync.Mutex,
+	events chan<- Event,
+) bool {
+	result := Result{
+		ID:        uuid.New(),
+		Project:   project,
+		StartTime: time.Now(),
+		Runs:      make([]Run, 0, options.WarmupRuns+options.Runs),
+	}
+
+	for i := 0; i < options.WarmupRuns+options.Runs && ctx.Err() == nil; i++ {
+		// the warm-up runs and the measured runs are numbered separately
+		warmup := i < options.WarmupRuns
+		currentRun, totalRuns := i+1, options.WarmupRuns
+		if !warmup {
+			currentRun, totalRuns = i-options.WarmupRuns+1, options.Runs
+		}
+
+		if options.Cache.Mode() == data.CacheCold {
+			cache.Lock()
+			ok := reset(ctx, env, events)
+			cache.Unlock()
+
+			if !ok {
+				if ctx.Err() == nil {
+					return false
+				}
+				break
+			}
+		}
+
+		events <- RunStarted{
+			Project:    project,
+			Worker:     worker,
+			StartTime:  time.Now(),
+			CurrentRun: currentRun,
+			TotalRuns:  totalRuns,
+			Warmup:     warmup,
+		}
+
+		command := []string{options.Target, project.GetName()}
+		if options.Command != nil {
+			command = options.Command(project)
+		}
+
+		output := newLineWriter(ctx, project, worker, events)
+		startTime := time.Now()
+		cache.RLock()
+		run := execTarget(ctx, options.args(command), env, logPath(result.ID, i+1), output)
+		if options.Tasks != nil && run.Status != data.RunCancelled {
+			run.Tasks = options.Tasks(run.Log, startTime)
+		}
+		cache.RUnlock()
+		output.Flush()
+
+		if warmup {
+			run.Excluded = data.ExcludedWarmup
+		}
+
+		result.Runs = append(result.Runs, run)
+
+		events <- RunFinished{
+			Project:    project,
+			Worker:     worker,
+			EndTime:    time.Now(),
+			CurrentRun: currentRun,
+			TotalRuns:  totalRuns,
+			Warmup:     warmup,
+			Duration:   run.Duration,
+			Status:     run.Status,
+			ExitCode:   run.ExitCode,
+			Error:      run.Error,
+			Log:        run.Log,
+		}
+	}
+
+	result.Cancelled = ctx.Err() != nil
+	result.rejectOutliers(options.Outliers)
+
+	finished := utils.Ternary(options.SuccessRequired, result.Succeeded(), result.TotalRuns() > 0)
+
+	if finished && (!result.Cancelled || options.KeepPartial) {
+		events <- StatsStarted{Project: project, Worker: worker, StartTime: time.Now()}
+
+		finish.Lock()
+		benchmark, err := options.Finish(result)
+		finish.Unlock()
+
+		events <- StatsWritten[T]{
+			Project:   project,
+			Worker:    worker,
+			Time:      time.Now(),
+			Benchmark: benchmark,
+			Error:     err,
+		}
+	}
+
+	return !result.Cancelled
+}
+
+func reset(ctx context.Context, env []string, events chan<- Event) bool {
+	events <- ResetStarted{Time: time.Now()}
+
+	var resetErr error
+	if err := run(command(ctx, env, "reset")); err != nil {
+		resetErr = utils.Ternary(ctx.Err() != nil, ErrCancelled, fmt.Errorf("nx reset failed: %v", err))
+	}
+
+	events <- ResetFinished{Time: time.Now(), Error: resetErr}
+
+	return resetErr == nil
+}
+
+func execTarget(ctx context.Context, args, env []string, log string, output io.Writer) Run {
+	cmd := command(ctx, env, args...)
+
+	// the run is not prevented by a log that cannot be created
+	logFile, err := createLog(log)
+	if err == nil {
+		defer logFile.Close()
+		output = io.MultiWriter(logFile, output)
+	} else {
+		log = ""
+	}
+
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	startTime := time.Now()
+
+	if err := run(cmd); err != nil {
+		if ctx.Err() != nil {
+			return Run{Status: data.RunCancelled, Error: ErrCancelled, Log: log}
+		}
+
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+
+		return Run{
+			Status:   data.RunFailed,
+			ExitCode: exitCode,
+			Error:    fmt.Errorf("%s failed: %v", args[0], err),
+			Log:      log,
+		}
+	}
+
+	return Run{Status: data.RunSucceeded, Duration: time.Since(startTime).Seconds(), Log: log}
+}
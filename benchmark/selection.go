@@ -0,0 +1,350 @@
+package benchmark
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ionut-t/gonx/ui/input"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"io"
+	"slices"
+	"strings"
+)
+
+// bulkSelectionKeys act on the projects shown by the list, i.e. on the filtered ones when a filter is applied.
+var bulkSelectionKeys = []key.Binding{
+	key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "select all")),
+	key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "select none")),
+	key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "invert selection")),
+	key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "select by tag")),
+	key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "select by type")),
+	key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "select by glob")),
+	key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save preset")),
+	key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "load preset")),
+}
+
+// selectVisible selects all (a), none (n) or the other (i) projects among the ones shown by the list.
+func (m *selectProjectsModel) selectVisible(keypress string) {
+	for _, item := range m.list.VisibleItems() {
+		name := item.(selectItem).item.GetName()
+
+		switch keypress {
+		case "a":
+			m.selected[name] = true
+		case "n":
+			delete(m.selected, name)
+		case "i":
+			if m.selected[name] {
+				delete(m.selected, name)
+			} else {
+				m.selected[name] = true
+			}
+		}
+	}
+
+	m.updateTitle()
+}
+
+// selectWhere adds the projects matching the predicate to the selection and reports how many matched.
+func (m *selectProjectsModel) selectWhere(description string, matches func(project workspace.Project) bool) {
+	count := 0
+
+	for _, project := range m.projects {
+		if matches(project) {
+			m.selected[project.GetName()] = true
+			count++
+		}
+	}
+
+	m.status = styles.DimText.Render(fmt.Sprintf("%d %s %s.", count, utils.Ternary(count == 1, "project", "projects"), description))
+	m.updateTitle()
+}
+
+type pickerKind int
+
+const (
+	tagPicker pickerKind = iota
+	typePicker
+	presetPicker
+)
+
+// selectionPicker lists the tags, the types or the presets the projects can be selected by.
+type selectionPicker struct {
+	list list.Model
+	kind pickerKind
+}
+
+func (m selectProjectsModel) updatePicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.list.SetWidth(msg.Width)
+		m.picker.list.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			item, ok := m.picker.list.SelectedItem().(pickerItem)
+			if !ok {
+				return m, nil
+			}
+
+			kind := m.picker.kind
+			m.picker = nil
+			m.selectPicked(kind, item.value)
+
+			return m, nil
+
+		case "esc", "backspace":
+			m.picker = nil
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.picker.list, cmd = m.picker.list.Update(msg)
+
+	return m, cmd
+}
+
+func (m *selectProjectsModel) selectPicked(kind pickerKind, value string) {
+	switch kind {
+	case tagPicker:
+		m.selectWhere("tagged "+value, func(project workspace.Project) bool {
+			return slices.Contains(project.GetTags(), value)
+		})
+
+	case typePicker:
+		m.selectWhere("of type "+value, func(project workspace.Project) bool {
+			return string(project.GetType()) == value
+		})
+
+	case presetPicker:
+		preset, err := workspace.FindPreset(value)
+		if err != nil {
+			m.status = styles.Error.Render(err.Error())
+			return
+		}
+
+		// a preset replaces the selection, leaving out the projects this benchmark cannot run
+		clear(m.selected)
+		m.selectWhere("selected from preset "+preset.Name, func(project workspace.Project) bool {
+			return slices.Contains(preset.Projects, project.GetName())
+		})
+	}
+}
+
+// openPicker shows the picker, or the reason why there is nothing to pick from.
+func (m *selectProjectsModel) openPicker(kind pickerKind) {
+	var (
+		title string
+		items []list.Item
+	)
+
+	switch kind {
+	case tagPicker:
+		title = "Select the projects tagged"
+
+		var tags []string
+		for _, project := range m.projects {
+			for _, tag := range project.GetTags() {
+				if !slices.Contains(tags, tag) {
+					tags = append(tags, tag)
+				}
+			}
+		}
+		slices.Sort(tags)
+
+		for _, tag := range tags {
+			count := len(utils.Filter(m.projects, func(project workspace.Project) bool {
+				return slices.Contains(project.GetTags(), tag)
+			}))
+			items = append(items, pickerItem{label: fmt.Sprintf("%s (%d)", tag, count), value: tag})
+		}
+
+	case typePicker:
+		title = "Select the projects of type"
+
+		for _, projectType := range []workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType, workspace.E2EType} {
+			count := len(utils.Filter(m.projects, func(project workspace.Project) bool {
+				return project.GetType() == projectType
+			}))
+			if count > 0 {
+				items = append(items, pickerItem{label: fmt.Sprintf("%s (%d)", projectType, count), value: string(projectType)})
+			}
+		}
+
+	case presetPicker:
+		title = "Select the projects of the preset"
+
+		presets, err := workspace.Presets()
+		if err != nil {
+			m.status = styles.Error.Render(err.Error())
+			return
+		}
+
+		for _, preset := range presets {
+			items = append(items, pickerItem{label: fmt.Sprintf("%s (%d %s)", preset.Name, len(preset.Projects), utils.Ternary(len(preset.Projects) == 1, "project", "projects")), value: preset.Name})
+		}
+	}
+
+	if len(items) == 0 {
+		m.status = styles.Warning.Render(utils.Ternary(kind == presetPicker, "No preset was saved yet.", "There is nothing to select by."))
+		return
+	}
+
+	m.picker = &selectionPicker{list: newPickerList(title, items, m.width, m.height), kind: kind}
+}
+
+func newPickerList(title string, items []list.Item, width, height int) list.Model {
+	pickerList := list.New(items, pickerItemDelegate{}, width, height)
+	pickerList.Title = title
+	pickerList.SetShowStatusBar(false)
+	pickerList.SetFilteringEnabled(false)
+	pickerList.Styles.Title = listTitleStyle
+	pickerList.Help.Styles.ShortKey = styles.Subtext0
+	pickerList.Help.Styles.ShortDesc = styles.Overlay1
+	pickerList.Help.Styles.ShortSeparator = styles.Subtext0
+	pickerList.Styles.HelpStyle = helpStyle
+	pickerList.InfiniteScrolling = true
+
+	pickerList.KeyMap = list.KeyMap{
+		CursorUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		CursorDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl-q", "ctrl-c"),
+			key.WithHelp("ctrl-(q/c)", "quit"),
+		),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c")),
+	}
+
+	pickerList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "select"),
+			),
+			key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "back"),
+			),
+		}
+	}
+
+	return pickerList
+}
+
+type pickerItem struct {
+	label string
+	value string
+}
+
+func (i pickerItem) FilterValue() string { return "" }
+
+type pickerItemDelegate struct{}
+
+func (d pickerItemDelegate) Height() int                             { return 1 }
+func (d pickerItemDelegate) Spacing() int                            { return 0 }
+func (d pickerItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d pickerItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(pickerItem)
+	if !ok {
+		return
+	}
+
+	if index == m.Index() {
+		_, _ = fmt.Fprint(w, currentItemStyle.Render("> "+i.label))
+		return
+	}
+
+	_, _ = fmt.Fprint(w, itemStyle.Render(i.label))
+}
+
+type promptKind int
+
+const (
+	globPrompt promptKind = iota
+	presetPrompt
+)
+
+// selectionPrompt asks for the glob to select projects by, or for the name to save the selection under.
+type selectionPrompt struct {
+	input input.Model
+	kind  promptKind
+}
+
+func newSelectionPrompt(kind promptKind) *selectionPrompt {
+	options := input.Options{Width: 60, Mode: input.Text, HideHelp: true}
+
+	if kind == globPrompt {
+		options.Label = "Select the projects whose name or folder matches"
+		options.Placeholder = "e.g. shared-* or libs/shared/**"
+	} else {
+		options.Label = "Save the selection as the preset"
+		options.Placeholder = "e.g. shared-ui-downstream"
+	}
+
+	prompt := &selectionPrompt{input: input.New(options), kind: kind}
+	prompt.input.Focus()
+
+	return prompt
+}
+
+func (m selectProjectsModel) updatePrompt(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.list.SetWidth(msg.Width)
+		return m, nil
+
+	case input.CancelMsg:
+		m.prompt = nil
+		return m, nil
+
+	case input.DoneMsg:
+		value := strings.TrimSpace(string(msg))
+		kind := m.prompt.kind
+		m.prompt = nil
+
+		if value == "" {
+			return m, nil
+		}
+
+		if kind == globPrompt {
+			m.selectWhere("matching "+value, func(project workspace.Project) bool {
+				return workspace.MatchGlob(value, project)
+			})
+
+			return m, nil
+		}
+
+		names := make([]string, 0, len(m.selected))
+		for _, project := range m.selectedProjects() {
+			names = append(names, project.GetName())
+		}
+
+		if err := workspace.SavePreset(value, names); err != nil {
+			m.status = styles.Error.Render(err.Error())
+			return m, nil
+		}
+
+		m.status = styles.Success.Render(fmt.Sprintf("Preset %s saved with %d %s.", value, len(names), utils.Ternary(len(names) == 1, "project", "projects")))
+
+		return m, nil
+	}
+
+	prompt, cmd := m.prompt.input.Update(msg)
+	m.prompt = &selectionPrompt{input: prompt.(input.Model), kind: m.prompt.kind}
+
+	return m, cmd
+}
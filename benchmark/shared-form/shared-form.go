@@ -6,18 +6,30 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
+	data "github.com/ionut-t/gonx/benchmark/data"
 	"github.com/ionut-t/gonx/internal/messages"
+	"runtime"
 	"strconv"
+	"strings"
 )
 
 type FormMsg struct {
 	Description string
 	Count       int
+	KeepPartial bool
+	WarmupRuns  int
+	Outliers    data.OutlierPolicy
+	Cache       data.Cache
+	Args        []string
+	Env         []string
+	Workers     int
 }
 
 type Model struct {
 	form *huh.Form
 	help help.Model
+	// targetOptions is set when the form only asks for the options of the target and the description
+	targetOptions bool
 }
 
 func New() Model {
@@ -46,27 +58,127 @@ func New() Model {
 			return nil
 		})
 
-	description := huh.NewInput().
-		Key("description").
-		Title("You can provide an optional description")
+	warmup := huh.NewInput().
+		Key("warmup").
+		Title("How many warm-up runs, excluded from the stats, should run first?").
+		Placeholder("0").
+		CharLimit(2).
+		Validate(func(str string) error {
+			if str == "" {
+				return nil
+			}
+
+			num, err := strconv.Atoi(str)
+			if err != nil {
+				return fmt.Errorf("please enter a valid number")
+			}
+
+			if num < 0 || num > 10 {
+				return fmt.Errorf("number must be between 0 and 10")
+			}
+
+			return nil
+		})
+
+	outliers := huh.NewSelect[string]().
+		Key("outliers").
+		Title("Reject the outliers before computing the stats?").
+		Options(
+			huh.NewOption("No", string(data.OutliersNone)),
+			huh.NewOption("Yes, using the interquartile range (IQR)", string(data.OutliersIQR)),
+			huh.NewOption("Yes, using the median absolute deviation (MAD)", string(data.OutliersMAD)),
+		)
+
+	cache := huh.NewSelect[string]().
+		Key("cache").
+		Title("How should the Nx cache be handled?").
+		Options(
+			huh.NewOption("Cold, reset before every run", string(data.CacheCold)),
+			huh.NewOption("Reset once, before the first run", string(data.CacheResetOnce)),
+			huh.NewOption("Warm, never reset", string(data.CacheWarm)),
+			huh.NewOption("Skip the cache with --skip-nx-cache", string(data.CacheSkip)),
+		)
+
+	daemon := huh.NewConfirm().
+		Key("daemon").
+		Title("Use the Nx daemon?").
+		Affirmative("Yes").
+		Negative("No")
+
+	workers := huh.NewInput().
+		Key("workers").
+		Title("How many projects should be benchmarked in parallel?").
+		Description(fmt.Sprintf("Running projects concurrently is faster, but the contention makes every run slower. This machine has %d CPUs.", runtime.NumCPU())).
+		Placeholder("1").
+		CharLimit(2).
+		Validate(func(str string) error {
+			if str == "" {
+				return nil
+			}
 
+			num, err := strconv.Atoi(str)
+			if err != nil {
+				return fmt.Errorf("please enter a valid number")
+			}
+
+			if num < 1 || num > 32 {
+				return fmt.Errorf("number must be between 1 and 32")
+			}
+
+			return nil
+		})
+
+	keepPartial := huh.NewConfirm().
+		Key("keepPartial").
+		Title("Keep the partial results if the benchmark is cancelled?").
+		Affirmative("Yes").
+		Negative("No")
+
+	return newModel(false, count, warmup, outliers, cache, daemon, workers, argsInput(), envInput(), descriptionInput(), keepPartial)
+}
+
+// NewTargetOptions creates the form of the benchmarks that run the target a single time, e.g. the bundle one,
+// which only asks for the extra arguments and environment variables of the target and for a description.
+func NewTargetOptions() Model {
+	return newModel(true, argsInput(), envInput(), descriptionInput())
+}
+
+func newModel(targetOptions bool, fields ...huh.Field) Model {
 	form := Model{
-		form: huh.NewForm(
-			huh.NewGroup(
-				count,
-				description,
-			),
-		).WithTheme(huh.ThemeCatppuccin()),
-		help: help.New(),
+		form:          huh.NewForm(huh.NewGroup(fields...)).WithTheme(huh.ThemeCatppuccin()),
+		help:          help.New(),
+		targetOptions: targetOptions,
 	}
 
 	form.form.WithKeyMap(&formKeyMap)
 	form.form.WithShowHelp(false)
-	count.Focus()
+	fields[0].Focus()
 
 	return form
 }
 
+func argsInput() *huh.Input {
+	return huh.NewInput().
+		Key("args").
+		Title("Extra arguments for the target, e.g. --coverage=false --ci")
+}
+
+func envInput() *huh.Input {
+	return huh.NewInput().
+		Key("env").
+		Title("Extra environment variables, e.g. NODE_OPTIONS=--max-old-space-size=8192").
+		Validate(func(str string) error {
+			_, err := data.ParseEnv(str)
+			return err
+		})
+}
+
+func descriptionInput() *huh.Input {
+	return huh.NewInput().
+		Key("description").
+		Title("You can provide an optional description")
+}
+
 func (m Model) Init() tea.Cmd {
 	return m.form.Init()
 }
@@ -111,6 +223,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) dispatchFormMsgIfValid() tea.Cmd {
+	env, err := data.ParseEnv(m.form.GetString("env"))
+	if err != nil {
+		return nil
+	}
+
+	if m.targetOptions {
+		return messages.Dispatch(FormMsg{
+			Count:       1,
+			Description: m.form.GetString("description"),
+			Args:        strings.Fields(m.form.GetString("args")),
+			Env:         env,
+			Workers:     1,
+		})
+	}
+
 	countStr := m.form.GetString("count")
 	count, err := strconv.Atoi(countStr)
 
@@ -118,9 +245,23 @@ func (m Model) dispatchFormMsgIfValid() tea.Cmd {
 		return nil
 	}
 
+	// an empty warm-up field means no warm-up runs and an empty workers field sequential projects
+	warmupRuns, _ := strconv.Atoi(m.form.GetString("warmup"))
+	workers, _ := strconv.Atoi(m.form.GetString("workers"))
+
 	return messages.Dispatch(FormMsg{
 		Count:       count,
 		Description: m.form.GetString("description"),
+		KeepPartial: m.form.GetBool("keepPartial"),
+		WarmupRuns:  warmupRuns,
+		Outliers:    data.OutlierPolicy(m.form.GetString("outliers")),
+		Cache: data.Cache{
+			CacheMode: data.CacheMode(m.form.GetString("cache")),
+			Daemon:    m.form.GetBool("daemon"),
+		},
+		Args:    strings.Fields(m.form.GetString("args")),
+		Env:     env,
+		Workers: max(1, workers),
 	})
 }
 
@@ -131,7 +272,7 @@ var formKeyMap = huh.KeyMap{
 	),
 	Confirm: huh.ConfirmKeyMap{
 		Next: key.NewBinding(
-			key.WithKeys("tab"),
+			key.WithKeys("enter", "tab"),
 			key.WithHelp("tab", "next"),
 		),
 		Prev: key.NewBinding(
@@ -142,6 +283,16 @@ var formKeyMap = huh.KeyMap{
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "submit"),
 		),
+		Toggle: key.NewBinding(key.WithKeys("h", "l", "right", "left"), key.WithHelp("←/→", "toggle")),
+		Accept: key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y", "yes")),
+		Reject: key.NewBinding(key.WithKeys("n", "N"), key.WithHelp("n", "no")),
+	},
+	Select: huh.SelectKeyMap{
+		Prev:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous")),
+		Next:   key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "select")),
+		Submit: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+		Up:     key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑", "up")),
+		Down:   key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓", "down")),
 	},
 	Input: huh.InputKeyMap{
 		AcceptSuggestion: key.NewBinding(key.WithKeys("ctrl+e"), key.WithHelp("ctrl+e", "complete")),
@@ -0,0 +1,172 @@
+package stats
+
+import (
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"math"
+	"slices"
+)
+
+// tDistribution holds the two-tailed 95% critical values of the Student's t-distribution,
+// indexed by the degrees of freedom.
+var tDistribution = [...]float64{
+	0,
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// Summarise describes the distribution of the given durations.
+func Summarise(durations []float64) data.Summary {
+	if len(durations) == 0 {
+		return data.Summary{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	mean := Mean(sorted)
+	stdDev := StdDev(sorted)
+	margin := ConfidenceMargin(sorted)
+
+	summary := data.Summary{
+		Min:     sorted[0],
+		Max:     sorted[len(sorted)-1],
+		Average: mean,
+		Median:  percentile(sorted, 50),
+		P90:     percentile(sorted, 90),
+		P95:     percentile(sorted, 95),
+		StdDev:  stdDev,
+		CILow:   mean - margin,
+		CIHigh:  mean + margin,
+	}
+
+	if mean > 0 {
+		summary.CV = stdDev / mean
+	}
+
+	return summary
+}
+
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+// StdDev returns the sample standard deviation of the values.
+func StdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	mean := Mean(values)
+
+	var sum float64
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// Percentile returns the p-th percentile of the values, interpolating between the closest ranks.
+func Percentile(values []float64, p float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	return percentile(sorted, p)
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// ConfidenceMargin returns the half-width of the 95% confidence interval of the mean of the values.
+func ConfidenceMargin(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	return tValue(len(values)-1) * StdDev(values) / math.Sqrt(float64(len(values)))
+}
+
+func tValue(degreesOfFreedom int) float64 {
+	switch {
+	case degreesOfFreedom < len(tDistribution):
+		return tDistribution[degreesOfFreedom]
+	case degreesOfFreedom < 60:
+		return 2.021
+	case degreesOfFreedom < 120:
+		return 2.000
+	default:
+		return 1.960
+	}
+}
+
+// Outliers returns the indexes of the values rejected by the policy.
+// At least 4 values are needed to tell an outlier apart.
+func Outliers(values []float64, policy data.OutlierPolicy) []int {
+	if len(values) < 4 {
+		return nil
+	}
+
+	var isOutlier func(value float64) bool
+
+	switch policy {
+	case data.OutliersIQR:
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+
+		q1, q3 := percentile(sorted, 25), percentile(sorted, 75)
+		iqr := q3 - q1
+
+		isOutlier = func(value float64) bool {
+			return value < q1-1.5*iqr || value > q3+1.5*iqr
+		}
+
+	case data.OutliersMAD:
+		median := Percentile(values, 50)
+
+		deviations := make([]float64, len(values))
+		for i, value := range values {
+			deviations[i] = math.Abs(value - median)
+		}
+
+		mad := Percentile(deviations, 50)
+		if mad == 0 {
+			return nil
+		}
+
+		isOutlier = func(value float64) bool {
+			return math.Abs(0.6745*(value-median)/mad) > 3.5
+		}
+
+	default:
+		return nil
+	}
+
+	var outliers []int
+
+	for i, value := range values {
+		if isOutlier(value) {
+			outliers = append(outliers, i)
+		}
+	}
+
+	return outliers
+}
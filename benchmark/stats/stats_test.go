@@ -0,0 +1,166 @@
+package stats
+
+import (
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"math"
+	"slices"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-4
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		p      float64
+		want   float64
+	}{
+		{"empty", nil, 50, 0},
+		{"single value", []float64{7}, 95, 7},
+		{"median of an odd count", []float64{1, 2, 3, 4, 5}, 50, 3},
+		{"median of an even count", []float64{1, 2, 3, 4}, 50, 2.5},
+		{"p90 interpolated", []float64{1, 2, 3, 4, 5}, 90, 4.6},
+		{"p95 interpolated", []float64{1, 2, 3, 4, 5}, 95, 4.8},
+		{"minimum", []float64{1, 2, 3, 4, 5}, 0, 1},
+		{"maximum", []float64{1, 2, 3, 4, 5}, 100, 5},
+		{"unsorted values", []float64{5, 1, 3}, 50, 3},
+		{"first quartile", []float64{10, 20}, 25, 12.5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Percentile(test.values, test.p); !almostEqual(got, test.want) {
+				t.Errorf("Percentile(%v, %v) = %v, want %v", test.values, test.p, got, test.want)
+			}
+		})
+	}
+}
+
+func TestPercentileKeepsTheOrderOfTheValues(t *testing.T) {
+	values := []float64{3, 1, 2}
+
+	Percentile(values, 50)
+
+	if !slices.Equal(values, []float64{3, 1, 2}) {
+		t.Errorf("Percentile sorted the values in place: %v", values)
+	}
+}
+
+func TestTValue(t *testing.T) {
+	tests := []struct {
+		degreesOfFreedom int
+		want             float64
+	}{
+		{1, 12.706},
+		{4, 2.776},
+		{30, 2.042},
+		{31, 2.021},
+		{59, 2.021},
+		{60, 2.000},
+		{119, 2.000},
+		{120, 1.960},
+		{1000, 1.960},
+	}
+
+	for _, test := range tests {
+		if got := tValue(test.degreesOfFreedom); got != test.want {
+			t.Errorf("tValue(%d) = %v, want %v", test.degreesOfFreedom, got, test.want)
+		}
+	}
+}
+
+func TestConfidenceMargin(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"no value", nil, 0},
+		{"single value", []float64{4}, 0},
+		{"equal values", []float64{2, 2, 2}, 0},
+		{"two values", []float64{2, 4}, 12.706},
+		{"five values", []float64{1, 2, 3, 4, 5}, 2.776 * math.Sqrt(2.5) / math.Sqrt(5)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ConfidenceMargin(test.values); !almostEqual(got, test.want) {
+				t.Errorf("ConfidenceMargin(%v) = %v, want %v", test.values, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	if got := Summarise(nil); got != (data.Summary{}) {
+		t.Errorf("Summarise(nil) = %+v, want an empty summary", got)
+	}
+
+	got := Summarise([]float64{5, 1, 4, 2, 3})
+	margin := 2.776 * math.Sqrt(2.5) / math.Sqrt(5)
+
+	want := data.Summary{
+		Min:     1,
+		Max:     5,
+		Average: 3,
+		Median:  3,
+		P90:     4.6,
+		P95:     4.8,
+		StdDev:  math.Sqrt(2.5),
+		CV:      math.Sqrt(2.5) / 3,
+		CILow:   3 - margin,
+		CIHigh:  3 + margin,
+	}
+
+	fields := []struct {
+		name      string
+		got, want float64
+	}{
+		{"min", got.Min, want.Min},
+		{"max", got.Max, want.Max},
+		{"average", got.Average, want.Average},
+		{"median", got.Median, want.Median},
+		{"p90", got.P90, want.P90},
+		{"p95", got.P95, want.P95},
+		{"std dev", got.StdDev, want.StdDev},
+		{"cv", got.CV, want.CV},
+		{"ci low", got.CILow, want.CILow},
+		{"ci high", got.CIHigh, want.CIHigh},
+	}
+
+	for _, field := range fields {
+		if !almostEqual(field.got, field.want) {
+			t.Errorf("Summarise: %s = %v, want %v", field.name, field.got, field.want)
+		}
+	}
+}
+
+func TestOutliers(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		policy data.OutlierPolicy
+		want   []int
+	}{
+		{"no policy", []float64{1, 2, 3, 4, 100}, data.OutliersNone, nil},
+		{"too few values", []float64{1, 2, 100}, data.OutliersIQR, nil},
+		{"iqr high outlier", []float64{1, 2, 3, 4, 100}, data.OutliersIQR, []int{4}},
+		{"iqr low outlier", []float64{-100, 10, 11, 12, 13}, data.OutliersIQR, []int{0}},
+		{"iqr no outlier", []float64{10, 11, 12, 13, 14}, data.OutliersIQR, nil},
+		{"mad high outlier", []float64{10, 11, 12, 13, 100}, data.OutliersMAD, []int{4}},
+		{"mad outlier in the middle", []float64{10, 100, 11, 12, 13}, data.OutliersMAD, []int{1}},
+		{"mad no outlier", []float64{10, 11, 12, 13, 14}, data.OutliersMAD, nil},
+		{"mad equal values", []float64{5, 5, 5, 5, 9}, data.OutliersMAD, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Outliers(test.values, test.policy); !slices.Equal(got, test.want) {
+				t.Errorf("Outliers(%v, %s) = %v, want %v", test.values, test.policy, got, test.want)
+			}
+		})
+	}
+}
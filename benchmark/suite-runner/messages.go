@@ -0,0 +1,8 @@
+package suite_runner
+
+type StartMsg struct{}
+
+// CompleteMsg is sent once the event stream of the suite is closed.
+type CompleteMsg struct{}
+
+type DoneMsg struct{}
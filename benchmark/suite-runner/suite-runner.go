@@ -0,0 +1,407 @@
+package suite_runner
+
+import (
+	"context"
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/benchmark/suite"
+	"github.com/ionut-t/gonx/internal/keymap"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/logs"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/ui/suspense"
+	"github.com/ionut-t/gonx/ui/tail"
+	"github.com/ionut-t/gonx/ui/viewport"
+	"github.com/ionut-t/gonx/utils"
+	"strings"
+	"time"
+)
+
+const resultTitle = "📊 Suite results"
+
+const padding = 2
+
+var runHint = fmt.Sprintf(
+	"Press %s to cancel the suite or %s to toggle the output.",
+	keymap.Cancel.Help().Key,
+	keymap.Output.Help().Key,
+)
+
+var logsHint = fmt.Sprintf("Press %s to browse the logs of the runs.", keymap.Logs.Help().Key)
+
+type view int
+
+const (
+	runView view = iota
+	resultsView
+	logsView
+)
+
+type stepStatus int
+
+const (
+	stepPending stepStatus = iota
+	stepRunning
+	stepSucceeded
+	stepFailed
+	stepSkipped
+)
+
+// step tracks the progress of a step of the suite.
+type step struct {
+	plan     suite.Plan
+	status   stepStatus
+	failed   int
+	recorded int
+}
+
+// Model runs every step of a suite, one after the other, and sums up the benchmarks recorded by each of them.
+type Model struct {
+	view     view
+	run      suite.Run
+	steps    []step
+	logs     logs.Model
+	viewport viewport.Model
+	suspense suspense.Model
+	progress progress.Model
+	output   tail.Model
+
+	width  int
+	height int
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	cancelled  bool
+	events     <-chan suite.Event
+	completed  int
+	totalSteps int
+	runLogs    []logs.Entry
+}
+
+func New(ctx context.Context, run suite.Run, width, height int) Model {
+	m := Model{
+		ctx:    ctx,
+		run:    run,
+		width:  width,
+		height: height,
+	}
+
+	for _, plan := range run.Steps {
+		m.steps = append(m.steps, step{plan: plan})
+		m.totalSteps += plan.TotalSteps()
+	}
+
+	m.suspense = suspense.New(fmt.Sprintf("Starting suite %s", run.Suite.Name), true)
+	m.progress = progress.New(progress.WithDefaultGradient())
+	m.progress.Width = m.width - padding*2
+	m.progress.PercentageStyle = styles.Primary
+	m.output = tail.New(m.statusView(), m.width, m.height)
+
+	return m
+}
+
+// Init starts the suite right away, its steps holding all the settings of the benchmarks.
+func (m Model) Init() tea.Cmd {
+	return messages.Dispatch(StartMsg{})
+}
+
+func (m Model) View() string {
+	switch m.view {
+	case runView:
+		return m.output.Under(m.statusView())
+
+	case resultsView:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.Header("", resultTitle),
+			m.viewport.View(),
+			lipgloss.NewStyle().Padding(0, 1).Render(styles.DimText.Render(logsHint)),
+		)
+
+	case logsView:
+		return m.logs.View()
+	}
+
+	return ""
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	if m.view == logsView {
+		if _, ok := msg.(logs.CloseMsg); ok {
+			m.view = resultsView
+			return m, nil
+		}
+
+		m.logs, cmd = m.logs.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.progress.Width = m.width - padding*2
+		m.output.SetSize(m.statusView(), m.width, m.height)
+
+	case StartMsg:
+		var ctx context.Context
+		ctx, m.cancel = context.WithCancel(m.ctx)
+		m.events = suite.Start(ctx, m.run)
+
+		return m, tea.Batch(
+			m.listen(),
+			m.suspense.Spinner.Tick,
+			m.progress.SetPercent(0.0),
+		)
+
+	case suite.StepStarted:
+		m.steps[msg.Index].status = stepRunning
+		m.suspense.Message = fmt.Sprintf("Step %d/%d: %s", msg.Index+1, len(m.steps), msg.Plan.Title())
+		m.output.SetSize(m.statusView(), m.width, m.height)
+		if workers := msg.Plan.Settings.Workers; workers > 1 {
+			m.output.Interleave(fmt.Sprintf("%s output of %d workers", msg.Plan.Analyser, workers))
+		}
+		return m, m.listen()
+
+	case suite.StepEvent:
+		return m.updateStep(msg)
+
+	case suite.StepFinished:
+		m.steps[msg.Index].failed = msg.Failed
+		m.steps[msg.Index].recorded = msg.Recorded
+		m.steps[msg.Index].status = utils.Ternary(msg.Failed > 0 || msg.Recorded == 0, stepFailed, stepSucceeded)
+		return m, m.listen()
+
+	case CompleteMsg:
+		m.cancel()
+		for i := range m.steps {
+			if m.steps[i].status == stepPending {
+				m.steps[i].status = stepSkipped
+			}
+		}
+
+		return m, tea.Sequence(
+			m.progress.SetPercent(1.0),
+			// wait for the progress bar to finish animating
+			tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
+				return DoneMsg{}
+			}),
+		)
+
+	case DoneMsg:
+		renderSuiteResults(&m)
+		m.suspense.Loading = false
+		m.view = resultsView
+
+	case spinner.TickMsg:
+		if m.suspense.Loading {
+			var suspenseModel tea.Model
+			suspenseModel, cmd = m.suspense.Update(msg)
+			m.suspense = suspenseModel.(suspense.Model)
+			return m, cmd
+		}
+
+	// FrameMsg is sent when the progress bar wants to animate itself
+	case progress.FrameMsg:
+		progressModel, cmd := m.progress.Update(msg)
+		m.progress = progressModel.(progress.Model)
+		return m, cmd
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keymap.Cancel):
+			if m.view == runView && m.cancel != nil && !m.cancelled {
+				m.cancel()
+				m.cancelled = true
+				m.suspense.Message = "Cancelling the suite..."
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Output):
+			if m.view == runView {
+				m.output.Toggle()
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Logs):
+			if m.view == resultsView {
+				m.logs = logs.New(m.runLogs, m.width, m.height)
+				m.view = logsView
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Back):
+			if m.view == resultsView {
+				return m, messages.Dispatch(messages.NavigateToViewMsg(0))
+			}
+		}
+	}
+
+	if m.view == resultsView {
+		viewportModel, cmd := m.viewport.Update(msg)
+		m.viewport = viewportModel.(viewport.Model)
+		cmds = append(cmds, cmd)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// updateStep follows the events of the benchmark of the step in progress.
+func (m Model) updateStep(msg suite.StepEvent) (tea.Model, tea.Cmd) {
+	prefix := fmt.Sprintf("Step %d/%d: ", msg.Index+1, len(m.steps))
+	parallel := m.steps[msg.Index].plan.Settings.Workers > 1
+
+	switch event := msg.Event.(type) {
+	case runner.ResetStarted:
+		m.suspense.Message = prefix + "resetting the Nx cache and stopping the daemon"
+
+	case runner.ResetFinished:
+		if event.Error != nil {
+			m.suspense.Message = prefix + event.Error.Error()
+		}
+
+	case runner.RunStarted:
+		m.suspense.Message = fmt.Sprintf("%s%s %s (%s)",
+			prefix,
+			m.steps[msg.Index].plan.Analyser,
+			styles.Primary.Bold(true).Render(event.Project.GetName()),
+			event.Label(),
+		)
+		if !parallel {
+			m.output.Reset(fmt.Sprintf("%s %s (%s)", m.steps[msg.Index].plan.Analyser, event.Project.GetName(), event.Label()))
+		}
+
+	case runner.Output:
+		m.output.Push(event.Project.GetName(), event.Lines...)
+
+	case runner.RunFinished:
+		m.completed++
+		if event.Error != nil {
+			m.suspense.Message = prefix + event.Error.Error()
+		}
+		if event.Log != "" {
+			m.runLogs = append(m.runLogs, logs.Entry{
+				Title:  fmt.Sprintf("%s%s %s (%s)", prefix, m.steps[msg.Index].plan.Analyser, event.Project.GetName(), event.Label()),
+				Path:   event.Log,
+				Failed: event.Status == data.RunFailed,
+			})
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
+
+	case runner.StatsStarted:
+		m.suspense.Message = fmt.Sprintf("%sWriting stats for %s...", prefix, styles.Primary.Bold(true).Render(event.Project.GetName()))
+
+	case runner.Written:
+		m.completed++
+		if event.Err() != nil {
+			m.suspense.Message = prefix + event.Err().Error()
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
+	}
+
+	return m, m.listen()
+}
+
+func (m Model) statusView() string {
+	lines := make([]string, 0, len(m.steps))
+
+	for i, s := range m.steps {
+		line := fmt.Sprintf("%d. %s", i+1, s.plan.Title())
+
+		switch s.status {
+		case stepPending:
+			lines = append(lines, styles.DimText.Render("○ "+line))
+		case stepRunning:
+			lines = append(lines, styles.Primary.Render("● "+line))
+		case stepSucceeded:
+			lines = append(lines, styles.Success.Render("✓ "+line))
+		case stepFailed:
+			lines = append(lines, styles.Error.Render("✗ "+line))
+		case stepSkipped:
+			lines = append(lines, styles.Warning.Render("- "+line))
+		}
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Padding(1, 1, 0).Render(m.suspense.View()),
+		lipgloss.NewStyle().Padding(1, 1).Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		lipgloss.NewStyle().Padding(0, 1).Render(m.progress.View()),
+		lipgloss.NewStyle().Padding(1, 1).Render(styles.DimText.Render(runHint)),
+	)
+}
+
+func (m Model) listen() tea.Cmd {
+	return messages.Listen(m.events, CompleteMsg{})
+}
+
+func (m Model) progressPercent() float64 {
+	return float64(m.completed) / float64(max(1, m.totalSteps))
+}
+
+func renderSuiteResults(m *Model) {
+	border := styles.NormalText.Render(strings.Repeat("─", min(50, m.width-padding)))
+
+	contents := []string{
+		fmt.Sprintf("Suite %s", styles.Primary.Bold(true).Render(data.SuiteRun{Suite: m.run.Suite.Name, SuiteRunID: m.run.ID}.String())),
+		styles.DimText.Render("The benchmarks of every step can be found in the history by searching for the suite and the run."),
+		"",
+	}
+
+	if m.cancelled {
+		contents = append(contents, styles.Warning.Render("The suite was cancelled, the results below are partial."), "")
+	}
+
+	for i, s := range m.steps {
+		var status string
+
+		switch s.status {
+		case stepSucceeded:
+			status = styles.Success.Render(fmt.Sprintf("✓ %d %s recorded", s.recorded, utils.Ternary(s.recorded == 1, "benchmark", "benchmarks")))
+		case stepFailed:
+			status = styles.Error.Render(fmt.Sprintf(
+				"✗ %d %s recorded, %d %s",
+				s.recorded,
+				utils.Ternary(s.recorded == 1, "benchmark", "benchmarks"),
+				s.failed,
+				utils.Ternary(s.failed == 1, "failure", "failures"),
+			))
+		default:
+			status = styles.Warning.Render("- skipped")
+		}
+
+		contents = append(contents,
+			border,
+			fmt.Sprintf("Step %d: %s", i+1, s.plan.Title()),
+			styles.DimText.Render(s.plan.Settings.Description),
+			status,
+		)
+	}
+
+	contents = append(contents, border)
+
+	output := lipgloss.NewStyle().
+		Padding(0, 4).
+		Render(lipgloss.JoinVertical(lipgloss.Left, contents...))
+
+	m.viewport = viewport.New(viewport.Options{
+		Width:   m.width,
+		Height:  m.viewportHeight(),
+		Content: output,
+	})
+}
+
+func (m Model) viewportHeight() int {
+	return m.height - lipgloss.Height(styles.Header(resultTitle)) - lipgloss.Height(logsHint)
+}
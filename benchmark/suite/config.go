@@ -0,0 +1,184 @@
+package suite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/internal/constants"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Analyser is the benchmark run by a step of a suite.
+type Analyser string
+
+const (
+	BundleAnalyser    Analyser = "bundle"
+	BuildAnalyser     Analyser = "build"
+	LintAnalyser      Analyser = "lint"
+	TestAnalyser      Analyser = "test"
+	TargetAnalyser    Analyser = "target"
+	WorkspaceAnalyser Analyser = "workspace"
+)
+
+var analysers = []Analyser{BundleAnalyser, BuildAnalyser, LintAnalyser, TestAnalyser, TargetAnalyser, WorkspaceAnalyser}
+
+// Config is the configuration of gonx, stored in .gonx/config.json.
+type Config struct {
+	Suites []Suite `json:"suites"`
+}
+
+// Suite is a named list of benchmarks run one after the other, e.g. the benchmarks of every release.
+type Suite struct {
+	Name string `json:"name"`
+	// Description is the template of the description of the benchmarks, unless a step has its own.
+	Description string `json:"description,omitempty"`
+	Steps       []Step `json:"steps"`
+}
+
+// Step is a benchmark of a suite, with the settings the analyser would otherwise ask for.
+// The settings left out get the defaults of the CLI.
+type Step struct {
+	Analyser Analyser `json:"analyser"`
+	// Target is the Nx target run by the target analyser, e.g. e2e.
+	Target string `json:"target,omitempty"`
+	// Operation, Targets, Base and Parallel describe the nx command run by the workspace analyser.
+	Operation data.WorkspaceOperation `json:"operation,omitempty"`
+	Targets   []string                `json:"targets,omitempty"`
+	Base      string                  `json:"base,omitempty"`
+	Parallel  int                     `json:"parallel,omitempty"`
+	// Projects are names or globs matched against the name and the folder of the projects, e.g. libs/core/*.
+	// All the projects the analyser can benchmark are used when neither the projects nor the preset are set.
+	Projects      []string           `json:"projects,omitempty"`
+	Preset        string             `json:"preset,omitempty"`
+	Runs          int                `json:"runs,omitempty"`
+	WarmupRuns    int                `json:"warmup,omitempty"`
+	Outliers      data.OutlierPolicy `json:"outliers,omitempty"`
+	Cache         data.CacheMode     `json:"cache,omitempty"`
+	Daemon        bool               `json:"daemon,omitempty"`
+	Workers       int                `json:"workers,omitempty"`
+	Configuration string             `json:"configuration,omitempty"`
+	Args          []string           `json:"args,omitempty"`
+	Env           []string           `json:"env,omitempty"`
+	Description   string             `json:"description,omitempty"`
+}
+
+// Suites returns the suites of the configuration, none when there is no configuration file.
+func Suites() ([]Suite, error) {
+	content, err := os.ReadFile(constants.ConfigFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("%s: %v", constants.ConfigFilePath, err)
+	}
+
+	for i, suite := range config.Suites {
+		if err := suite.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %v", constants.ConfigFilePath, err)
+		}
+
+		if slices.ContainsFunc(config.Suites[:i], func(other Suite) bool { return other.Name == suite.Name }) {
+			return nil, fmt.Errorf("%s: suite %s is defined twice", constants.ConfigFilePath, suite.Name)
+		}
+	}
+
+	return config.Suites, nil
+}
+
+// Find returns the suite with the given name.
+func Find(name string) (Suite, error) {
+	suites, err := Suites()
+	if err != nil {
+		return Suite{}, err
+	}
+
+	idx := slices.IndexFunc(suites, func(suite Suite) bool {
+		return suite.Name == name
+	})
+
+	if idx == -1 {
+		return Suite{}, fmt.Errorf("suite %s not found in %s", name, constants.ConfigFilePath)
+	}
+
+	return suites[idx], nil
+}
+
+func (s Suite) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("a suite has no name")
+	}
+
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("suite %s has no steps", s.Name)
+	}
+
+	for i, step := range s.Steps {
+		if err := step.validate(); err != nil {
+			return fmt.Errorf("step %d of suite %s: %v", i+1, s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validate applies the bounds of the CLI flags to the settings of the step.
+func (s Step) validate() error {
+	if !slices.Contains(analysers, s.Analyser) {
+		return fmt.Errorf("analyser must be one of bundle, build, lint, test, target or workspace")
+	}
+
+	if s.Analyser == TargetAnalyser && s.Target == "" {
+		return fmt.Errorf("target is required by the target analyser")
+	}
+
+	if s.Analyser == WorkspaceAnalyser {
+		if len(s.Targets) == 0 {
+			return fmt.Errorf("targets are required by the workspace analyser")
+		}
+
+		if s.Operation != "" && s.Operation != data.OperationRunMany && s.Operation != data.OperationAffected {
+			return fmt.Errorf("operation must be either run-many or affected")
+		}
+	}
+
+	if len(s.Projects) > 0 && s.Preset != "" {
+		return fmt.Errorf("projects and preset cannot be used together")
+	}
+
+	if s.Runs < 0 || s.Runs > 100 {
+		return fmt.Errorf("runs must be between 1 and 100")
+	}
+
+	if s.WarmupRuns < 0 || s.WarmupRuns > 10 {
+		return fmt.Errorf("warmup must be between 0 and 10")
+	}
+
+	if s.Workers < 0 || s.Workers > 32 {
+		return fmt.Errorf("workers must be between 1 and 32")
+	}
+
+	switch s.Outliers {
+	case "", data.OutliersNone, data.OutliersIQR, data.OutliersMAD:
+	default:
+		return fmt.Errorf("outliers must be one of none, iqr or mad")
+	}
+
+	if s.Cache != "" && !slices.Contains(data.CacheModes, s.Cache) {
+		return fmt.Errorf("cache must be one of cold, reset-once, warm or skip-nx-cache")
+	}
+
+	if _, err := data.ParseEnv(strings.Join(s.Env, " ")); err != nil {
+		return fmt.Errorf("env: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,337 @@
+package suite
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	bundleAnalyser "github.com/ionut-t/gonx/benchmark/bundle-analyser"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	testsAnalyser "github.com/ionut-t/gonx/benchmark/tests-analyser"
+	workspaceAnalyser "github.com/ionut-t/gonx/benchmark/workspace-analyser"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultDescription is the description template of the steps when neither the step nor the suite has one.
+const defaultDescription = "{suite}"
+
+// Run is an execution of a suite, whose steps are resolved against the workspace before any of them is started.
+// All the benchmarks it records are tagged with the name of the suite and the ID of the run.
+type Run struct {
+	Suite     Suite
+	ID        string
+	StartTime time.Time
+	Steps     []Plan
+}
+
+// Plan is a step of a suite run, with its projects and settings.
+type Plan struct {
+	Step
+	Projects  []workspace.Project
+	Settings  runner.Settings
+	operation workspaceAnalyser.Operation
+}
+
+// New resolves the steps of the suite against the workspace.
+func New(suite Suite, ws *workspace.Model) (Run, error) {
+	run := Run{Suite: suite, ID: uuid.New().String(), StartTime: time.Now()}
+	tag := data.SuiteRun{Suite: suite.Name, SuiteRunID: run.ID}
+
+	for i, step := range suite.Steps {
+		plan, err := newPlan(run, i, step, ws, tag)
+		if err != nil {
+			return Run{}, fmt.Errorf("step %d of suite %s: %v", i+1, suite.Name, err)
+		}
+
+		run.Steps = append(run.Steps, plan)
+	}
+
+	return run, nil
+}
+
+func newPlan(run Run, index int, step Step, ws *workspace.Model, tag data.SuiteRun) (Plan, error) {
+	plan := Plan{
+		Step: step,
+		Settings: runner.Settings{
+			Description: run.description(index, step),
+			Runs:        max(1, step.Runs),
+			WarmupRuns:  step.WarmupRuns,
+			Outliers:    utils.Ternary(step.Outliers == "", data.OutliersNone, step.Outliers),
+			Cache:       data.Cache{CacheMode: utils.Ternary(step.Cache == "", data.CacheCold, step.Cache), Daemon: step.Daemon},
+			TargetOptions: data.TargetOptions{
+				Configuration: step.Configuration,
+				Args:          step.Args,
+				Env:           step.Env,
+			},
+			Workers: max(1, step.Workers),
+			Suite:   tag,
+		},
+	}
+
+	if step.Analyser == WorkspaceAnalyser {
+		plan.operation = workspaceAnalyser.Operation{
+			Kind:     utils.Ternary(step.Operation == "", data.OperationRunMany, step.Operation),
+			Targets:  step.Targets,
+			Base:     step.Base,
+			Parallel: step.Parallel,
+		}
+
+		if plan.operation.Kind == data.OperationRunMany {
+			projects, err := selectProjects(eligibleProjects(step, ws), step)
+			if err != nil {
+				return Plan{}, err
+			}
+
+			// run-many runs all the projects by default, without listing them
+			if len(step.Projects) > 0 || step.Preset != "" {
+				for _, project := range projects {
+					plan.operation.Projects = append(plan.operation.Projects, project.GetName())
+				}
+			}
+		}
+
+		plan.Projects = []workspace.Project{plan.operation}
+
+		return plan, nil
+	}
+
+	projects, err := selectProjects(eligibleProjects(step, ws), step)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	plan.Projects = projects
+
+	return plan, nil
+}
+
+// description replaces the placeholders of the description template of the step,
+// e.g. "{suite} #{step} on {date}" becomes "release #2 on 2024-05-03".
+func (r Run) description(index int, step Step) string {
+	template := step.Description
+	if template == "" {
+		template = r.Suite.Description
+	}
+
+	if template == "" {
+		template = defaultDescription
+	}
+
+	return strings.NewReplacer(
+		"{suite}", r.Suite.Name,
+		"{step}", strconv.Itoa(index+1),
+		"{analyser}", string(step.Analyser),
+		"{target}", step.target(),
+		"{date}", r.StartTime.Format(time.DateOnly),
+		"{run}", r.ID[:8],
+	).Replace(template)
+}
+
+// target returns the Nx target run by the step, e.g. build or build,lint for the workspace analyser.
+func (s Step) target() string {
+	switch s.Analyser {
+	case BundleAnalyser, BuildAnalyser:
+		return "build"
+	case TargetAnalyser:
+		return s.Target
+	case WorkspaceAnalyser:
+		return strings.Join(s.Targets, ",")
+	}
+
+	return string(s.Analyser)
+}
+
+// eligibleProjects returns the projects of the workspace the analyser of the step can benchmark.
+func eligibleProjects(step Step, ws *workspace.Model) []workspace.Project {
+	switch step.Analyser {
+	case BundleAnalyser, BuildAnalyser:
+		return ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType})
+	case LintAnalyser, TestAnalyser:
+		return ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType})
+	case TargetAnalyser:
+		return ws.GetProjectsWithTarget(step.Target)
+	}
+
+	return ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType, workspace.E2EType})
+}
+
+// selectProjects returns the eligible projects matching the projects or the preset of the step,
+// or all of them when the step sets neither. Every name or glob of the step must match a project.
+func selectProjects(eligible []workspace.Project, step Step) ([]workspace.Project, error) {
+	if step.Preset != "" {
+		preset, err := workspace.FindPreset(step.Preset)
+		if err != nil {
+			return nil, err
+		}
+
+		selected := utils.Filter(eligible, func(project workspace.Project) bool {
+			return slices.Contains(preset.Projects, project.GetName())
+		})
+
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("no project of preset %s can run %s", step.Preset, step.target())
+		}
+
+		return selected, nil
+	}
+
+	if len(step.Projects) == 0 {
+		if len(eligible) == 0 {
+			return nil, fmt.Errorf("no project can run %s", step.target())
+		}
+
+		return eligible, nil
+	}
+
+	for _, pattern := range step.Projects {
+		if !slices.ContainsFunc(eligible, func(project workspace.Project) bool { return workspace.MatchGlob(pattern, project) }) {
+			return nil, fmt.Errorf("no project matching %s can run %s", pattern, step.target())
+		}
+	}
+
+	return utils.Filter(eligible, func(project workspace.Project) bool {
+		return slices.ContainsFunc(step.Projects, func(pattern string) bool { return workspace.MatchGlob(pattern, project) })
+	}), nil
+}
+
+// Title describes the plan, e.g. "build of shop, admin (5 runs, cold)".
+func (p Plan) Title() string {
+	names := make([]string, 0, len(p.Projects))
+	for _, project := range p.Projects {
+		names = append(names, project.GetName())
+	}
+
+	if p.Analyser == BundleAnalyser {
+		return fmt.Sprintf("bundle of %s", strings.Join(names, ", "))
+	}
+
+	if p.Analyser == WorkspaceAnalyser {
+		return fmt.Sprintf("nx %s (%d %s, %s)", names[0], p.Settings.Runs, utils.Ternary(p.Settings.Runs == 1, "run", "runs"), p.Settings.Cache.Short())
+	}
+
+	return fmt.Sprintf(
+		"%s of %s (%d %s, %s)",
+		p.target(),
+		strings.Join(names, ", "),
+		p.Settings.Runs,
+		utils.Ternary(p.Settings.Runs == 1, "run", "runs"),
+		p.Settings.Cache.Short(),
+	)
+}
+
+// Start starts the benchmark of the plan with its analyser and returns its event stream.
+func (p Plan) Start(ctx context.Context) <-chan runner.Event {
+	switch p.Analyser {
+	case BundleAnalyser:
+		apps := make([]workspace.Application, 0, len(p.Projects))
+		for _, project := range p.Projects {
+			apps = append(apps, project.(workspace.Application))
+		}
+
+		return bundleAnalyser.Run(ctx, apps, p.Settings.Description, p.Settings.TargetOptions, p.Settings.Suite)
+
+	case BuildAnalyser:
+		return testsAnalyser.Run(ctx, testsAnalyser.Build, p.Projects, p.Settings)
+
+	case LintAnalyser:
+		return testsAnalyser.Run(ctx, testsAnalyser.Lint, p.Projects, p.Settings)
+
+	case TestAnalyser:
+		return testsAnalyser.Run(ctx, testsAnalyser.Tests, p.Projects, p.Settings)
+
+	case TargetAnalyser:
+		return testsAnalyser.Run(ctx, testsAnalyser.Target(p.Target), p.Projects, p.Settings)
+	}
+
+	return workspaceAnalyser.Run(ctx, p.operation, p.Settings)
+}
+
+// TotalSteps returns the number of runs and stats of the plan, to track the progress of the suite.
+func (p Plan) TotalSteps() int {
+	if p.Analyser == BundleAnalyser {
+		return len(p.Projects) * 2
+	}
+
+	return len(p.Projects) * (p.Settings.WarmupRuns + p.Settings.Runs + 1)
+}
+
+// Event is sent on the stream returned by Start while a suite is in progress.
+type Event interface {
+	suiteEvent()
+}
+
+// StepStarted is sent before the benchmark of a step is started.
+type StepStarted struct {
+	Index int
+	Plan  Plan
+}
+
+// StepEvent carries an event of the benchmark of the step in progress.
+type StepEvent struct {
+	Index int
+	Event runner.Event
+}
+
+// StepFinished is sent once the benchmark of a step is over.
+// Failed counts the failed runs and resets and the benchmarks that could not be recorded.
+type StepFinished struct {
+	Index    int
+	Failed   int
+	Recorded int
+}
+
+func (StepStarted) suiteEvent()  {}
+func (StepEvent) suiteEvent()    {}
+func (StepFinished) suiteEvent() {}
+
+// Start runs the steps one after the other in a separate goroutine and returns the event stream of the suite.
+// A failed step doesn't stop the suite, while cancelling ctx skips the remaining steps.
+func Start(ctx context.Context, run Run) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		for i, plan := range run.Steps {
+			if ctx.Err() != nil {
+				return
+			}
+
+			events <- StepStarted{Index: i, Plan: plan}
+
+			finished := StepFinished{Index: i}
+
+			for event := range plan.Start(ctx) {
+				switch event := event.(type) {
+				case runner.ResetFinished:
+					if event.Error != nil {
+						finished.Failed++
+					}
+
+				case runner.RunFinished:
+					if event.Error != nil {
+						finished.Failed++
+					}
+
+				case runner.Written:
+					if event.Err() != nil {
+						finished.Failed++
+					} else {
+						finished.Recorded++
+					}
+				}
+
+				events <- StepEvent{Index: i, Event: event}
+			}
+
+			events <- finished
+		}
+	}()
+
+	return events
+}
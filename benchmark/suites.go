@@ -0,0 +1,100 @@
+package benchmark
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ionut-t/gonx/benchmark/suite"
+	"github.com/ionut-t/gonx/internal/constants"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"slices"
+)
+
+// suiteSelectedMsg is sent once the suite to run is picked and its steps resolved against the workspace.
+type suiteSelectedMsg suite.Run
+
+type selectSuiteModel struct {
+	list      list.Model
+	suites    []suite.Suite
+	workspace workspace.Model
+	// status explains why the configuration or the picked suite cannot be run
+	status string
+}
+
+func (m selectSuiteModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m selectSuiteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			item, ok := m.list.SelectedItem().(pickerItem)
+			if !ok {
+				return m, nil
+			}
+
+			idx := slices.IndexFunc(m.suites, func(s suite.Suite) bool {
+				return s.Name == item.value
+			})
+
+			run, err := suite.New(m.suites[idx], &m.workspace)
+			if err != nil {
+				m.status = styles.Error.Render(err.Error())
+				return m, nil
+			}
+
+			return m, messages.Dispatch(suiteSelectedMsg(run))
+
+		case "esc", "backspace":
+			return m, messages.Dispatch(messages.NavigateToViewMsg(0))
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m selectSuiteModel) View() string {
+	if len(m.suites) == 0 {
+		return "\n" + listTitleStyle.Render(utils.Ternary(
+			m.status != "",
+			m.status,
+			styles.Warning.Render(fmt.Sprintf("No suite is defined in %s.", constants.ConfigFilePath)),
+		))
+	}
+
+	if m.status != "" {
+		return "\n" + m.list.View() + "\n" + listTitleStyle.Render(m.status)
+	}
+
+	return "\n" + m.list.View()
+}
+
+func newSuitesList(ws workspace.Model, width, height int) selectSuiteModel {
+	suites, err := suite.Suites()
+	if err != nil {
+		return selectSuiteModel{status: styles.Error.Render(err.Error())}
+	}
+
+	items := make([]list.Item, len(suites))
+	for i, s := range suites {
+		label := fmt.Sprintf("%s (%d %s)", s.Name, len(s.Steps), utils.Ternary(len(s.Steps) == 1, "step", "steps"))
+		items[i] = pickerItem{label: label, value: s.Name}
+	}
+
+	return selectSuiteModel{
+		list:      newPickerList("Select the suite to run", items, width, height-1),
+		suites:    suites,
+		workspace: ws,
+	}
+}
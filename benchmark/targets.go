@@ -0,0 +1,128 @@
+package benchmark
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/styles"
+	"io"
+)
+
+// targetSelectedMsg is sent once the target of the target analyser is picked.
+type targetSelectedMsg string
+
+type selectTargetModel struct {
+	list list.Model
+}
+
+func (m selectTargetModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m selectTargetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			target, ok := m.list.SelectedItem().(targetItem)
+			if !ok {
+				return m, nil
+			}
+
+			return m, messages.Dispatch(targetSelectedMsg(target))
+
+		case "esc", "backspace":
+			return m, messages.Dispatch(messages.NavigateToViewMsg(0))
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m selectTargetModel) View() string {
+	if len(m.list.Items()) == 0 {
+		return "\n" + listTitleStyle.Render(styles.Warning.Render("No target was found in the workspace."))
+	}
+
+	return "\n" + m.list.View()
+}
+
+func newTargetsList(targets []string, width, height int) selectTargetModel {
+	items := make([]list.Item, len(targets))
+	for i, target := range targets {
+		items[i] = targetItem(target)
+	}
+
+	targetsList := list.New(items, targetItemDelegate{}, width, height)
+	targetsList.Title = "Select the target to benchmark"
+	targetsList.SetShowStatusBar(false)
+	targetsList.SetFilteringEnabled(false)
+	targetsList.Styles.Title = listTitleStyle
+	targetsList.Help.Styles.ShortKey = styles.Subtext0
+	targetsList.Help.Styles.ShortDesc = styles.Overlay1
+	targetsList.Help.Styles.ShortSeparator = styles.Subtext0
+	targetsList.Styles.HelpStyle = helpStyle
+	targetsList.InfiniteScrolling = true
+
+	targetsList.KeyMap = list.KeyMap{
+		CursorUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		CursorDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl-q", "ctrl-c"),
+			key.WithHelp("ctrl-(q/c)", "quit"),
+		),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c")),
+	}
+
+	targetsList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "confirm"),
+			),
+			key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "back"),
+			),
+		}
+	}
+
+	return selectTargetModel{list: targetsList}
+}
+
+type targetItem string
+
+func (i targetItem) FilterValue() string { return "" }
+
+type targetItemDelegate struct{}
+
+func (d targetItemDelegate) Height() int                             { return 1 }
+func (d targetItemDelegate) Spacing() int                            { return 0 }
+func (d targetItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d targetItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(targetItem)
+	if !ok {
+		return
+	}
+
+	if index == m.Index() {
+		_, _ = fmt.Fprint(w, currentItemStyle.Render("> "+string(i)))
+		return
+	}
+
+	_, _ = fmt.Fprint(w, itemStyle.Render(string(i)))
+}
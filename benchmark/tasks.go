@@ -9,6 +9,7 @@ import (
 	"github.com/ionut-t/gonx/internal/keymap"
 	"github.com/ionut-t/gonx/ui/help"
 	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/workspace"
 	"io"
 	"strings"
 )
@@ -21,11 +22,17 @@ var (
 	helpStyle         = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
 )
 
+const tasksTitle = "Select a task"
+
 var tasks = [...]string{
 	"Bundle analyser",
 	"Build analyser",
 	"Lint analyser",
 	"Tests analyser (experimental)",
+	"Target analyser",
+	"Workspace analyser (run-many / affected)",
+	"Project graph explorer",
+	"Run suite",
 }
 
 type taskType int
@@ -35,16 +42,60 @@ const (
 	buildAnalyserTask
 	lintAnalyserTask
 	testsAnalyserTask
+	targetAnalyserTask
+	workspaceAnalyserTask
+	projectGraphTask
+	suiteTask
 )
 
 type taskMsg taskType
 
+// target returns the Nx target run by the task.
+func (t taskType) target() string {
+	switch t {
+	case lintAnalyserTask:
+		return "lint"
+	case testsAnalyserTask:
+		return "test"
+	}
+
+	return "build"
+}
+
+// projects returns the projects of the workspace the task can benchmark.
+// The target analyser has none until its target is picked.
+func (t taskType) projects(ws workspace.Model) []workspace.Project {
+	switch t {
+	case bundleAnalyserTask, buildAnalyserTask:
+		return ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType})
+	case lintAnalyserTask, testsAnalyserTask:
+		return ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType})
+	case workspaceAnalyserTask:
+		return ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType, workspace.E2EType})
+	}
+
+	return nil
+}
+
 type tasksModel struct {
 	list     list.Model
 	selected taskType
 	help     string
 }
 
+// setScanning shows in the title whether the workspace is being scanned in the background,
+// or how many projects the last scan couldn't read.
+func (m *tasksModel) setScanning(scanning bool, skipped int) {
+	switch {
+	case scanning:
+		m.list.Title = tasksTitle + styles.DimText.Render(" · scanning the workspace...")
+	case skipped > 0:
+		m.list.Title = tasksTitle + styles.Warning.Render(fmt.Sprintf(" · %d project(s) could not be read", skipped))
+	default:
+		m.list.Title = tasksTitle
+	}
+}
+
 func (m tasksModel) Init() tea.Cmd {
 	return nil
 }
@@ -92,7 +143,7 @@ func newTasksList(width, height int) tasksModel {
 	const defaultWidth = 20
 
 	tasksList := list.New(items, taskItemDelegate{}, defaultWidth, height)
-	tasksList.Title = "Select a task"
+	tasksList.Title = tasksTitle
 	tasksList.SetShowStatusBar(false)
 	tasksList.SetFilteringEnabled(false)
 	tasksList.Styles.Title = listTitleStyle
@@ -161,6 +212,22 @@ func newTasksList(width, height int) tasksModel {
 					styles.Overlay1.Render(keymap.TestsAnalyserHistory.Help().Desc),
 				),
 			),
+		lipgloss.NewStyle().
+			Padding(0, 1).
+			Render(
+				fmt.Sprintf("%s %s",
+					styles.Subtext0.Render(keymap.TargetAnalyserHistory.Help().Key),
+					styles.Overlay1.Render(keymap.TargetAnalyserHistory.Help().Desc),
+				),
+			),
+		lipgloss.NewStyle().
+			Padding(0, 1).
+			Render(
+				fmt.Sprintf("%s %s",
+					styles.Subtext0.Render(keymap.Rescan.Help().Key),
+					styles.Overlay1.Render(keymap.Rescan.Help().Desc),
+				),
+			),
 	)
 
 	helpView := helpStyle.Render(listHelp.View() + "\n\n" + historyKeys)
@@ -13,15 +13,74 @@ import (
 	"github.com/ionut-t/gonx/internal/messages"
 	"github.com/ionut-t/gonx/ui/help"
 	"github.com/ionut-t/gonx/ui/input"
+	"github.com/ionut-t/gonx/ui/logs"
 	"github.com/ionut-t/gonx/ui/styles"
 	"github.com/ionut-t/gonx/ui/viewport"
+	"github.com/ionut-t/gonx/utils"
 	"os"
 	"strings"
 )
 
 const padding = 2
 
-const title = "📊 Tests Analyser History"
+// History is a history of the benchmarks written by the tests analyser.
+type History struct {
+	Title string
+	File  string
+	// Targets is set when the benchmarks are of any target, which is then shown along with the app.
+	Targets bool
+	// Keys are the keys of the other histories.
+	Keys keymap.Model
+}
+
+// Build is the history of the benchmarks of the build target.
+var Build = History{
+	Title: "📊 Build Analyser History",
+	File:  constants.BuildAnalyserFilePath,
+	Keys: keymap.Model{
+		BundleAnalyserHistory: keymap.BundleAnalyserHistory,
+		LintAnalyserHistory:   keymap.LintAnalyserHistory,
+		TestsAnalyserHistory:  keymap.TestsAnalyserHistory,
+		TargetAnalyserHistory: keymap.TargetAnalyserHistory,
+	},
+}
+
+// Lint is the history of the benchmarks of the lint target.
+var Lint = History{
+	Title: "📊 Lint Analyser History",
+	File:  constants.LintAnalyserFilePath,
+	Keys: keymap.Model{
+		BundleAnalyserHistory: keymap.BundleAnalyserHistory,
+		BuildAnalyserHistory:  keymap.BuildAnalyserHistory,
+		TestsAnalyserHistory:  keymap.TestsAnalyserHistory,
+		TargetAnalyserHistory: keymap.TargetAnalyserHistory,
+	},
+}
+
+// Tests is the history of the benchmarks of the test target.
+var Tests = History{
+	Title: "📊 Tests Analyser History",
+	File:  constants.TestAnalyserFilePath,
+	Keys: keymap.Model{
+		BundleAnalyserHistory: keymap.BundleAnalyserHistory,
+		BuildAnalyserHistory:  keymap.BuildAnalyserHistory,
+		LintAnalyserHistory:   keymap.LintAnalyserHistory,
+		TargetAnalyserHistory: keymap.TargetAnalyserHistory,
+	},
+}
+
+// Targets is the history of the benchmarks of the other targets.
+var Targets = History{
+	Title:   "📊 Target Analyser History",
+	File:    constants.TargetAnalyserFilePath,
+	Targets: true,
+	Keys: keymap.Model{
+		BundleAnalyserHistory: keymap.BundleAnalyserHistory,
+		BuildAnalyserHistory:  keymap.BuildAnalyserHistory,
+		LintAnalyserHistory:   keymap.LintAnalyserHistory,
+		TestsAnalyserHistory:  keymap.TestsAnalyserHistory,
+	},
+}
 
 type view int
 
@@ -32,20 +91,24 @@ const (
 )
 
 type Model struct {
+	history  History
 	view     view
 	metrics  []data.TestBenchmark
 	viewport viewport.Model
 	table    tableModel
 	search   input.Model
-	error    error
+	logs     logs.Model
+	// logsOpened is set while the log viewer covers the history
+	logsOpened bool
+	error      error
 
 	help help.Model
 
 	width, height int
 }
 
-func New(width, height int) Model {
-	metrics, err := readAllMetrics()
+func New(history History, width, height int) Model {
+	metrics, err := readAllMetrics(history.File)
 
 	if err == nil && len(metrics) == 0 {
 		err = os.ErrNotExist
@@ -53,24 +116,19 @@ func New(width, height int) Model {
 
 	helpMenu := help.New(width, height)
 
+	keys := history.Keys
+
 	if err != nil {
-		helpMenu.SetKeyMap(keymap.Model{
-			BundleAnalyserHistory: keymap.BundleAnalyserHistory,
-			BuildAnalyserHistory:  keymap.BuildAnalyserHistory,
-			LintAnalyserHistory:   keymap.LintAnalyserHistory,
-			Back:                  keymap.Back,
-			Quit:                  keymap.Quit,
-			Help:                  keymap.Help,
-		})
+		keys.Back = keymap.Back
+		keys.Quit = keymap.Quit
+		keys.Help = keymap.Help
+		helpMenu.SetKeyMap(keys)
 	} else {
-		helpMenu.CombineWithHistoryKeys(keymap.Model{
-			BundleAnalyserHistory: keymap.BundleAnalyserHistory,
-			BuildAnalyserHistory:  keymap.BuildAnalyserHistory,
-			LintAnalyserHistory:   keymap.LintAnalyserHistory,
-		})
+		helpMenu.CombineWithHistoryKeys(keys)
 	}
 
 	model := Model{
+		history: history,
 		view:    listView,
 		metrics: metrics,
 		error:   err,
@@ -78,7 +136,7 @@ func New(width, height int) Model {
 		height:  height,
 		search: input.New(input.Options{
 			Width:       60,
-			Placeholder: "Search by app name or description",
+			Placeholder: utils.Ternary(history.Targets, "Search by target or app name", "Search by app name or description"),
 			Mode:        input.Text,
 			HideHelp:    true,
 		}),
@@ -87,7 +145,7 @@ func New(width, height int) Model {
 
 	options := viewport.Options{
 		Width:   model.width,
-		Height:  model.height - lipgloss.Height(styles.Header(model.search.View(), title)) - lipgloss.Height(model.help.View()),
+		Height:  model.height - lipgloss.Height(styles.Header(model.search.View(), history.Title)) - lipgloss.Height(model.help.View()),
 		Content: getListContent(model),
 	}
 
@@ -105,7 +163,7 @@ func (m Model) View() string {
 		if errors.Is(m.error, os.ErrNotExist) {
 			return lipgloss.JoinVertical(
 				lipgloss.Top,
-				styles.Header("", title),
+				styles.Header("", m.history.Title),
 				lipgloss.NewStyle().Padding(1, 1).Render(
 					styles.Warning.Render("You don't have any metrics recorded yet."),
 				),
@@ -117,11 +175,15 @@ func (m Model) View() string {
 		return fmt.Sprintf("Error reading metrics: %s", m.error)
 	}
 
+	if m.logsOpened {
+		return m.logs.View()
+	}
+
 	switch m.view {
 	case listView, jsonView:
 		return lipgloss.JoinVertical(
 			lipgloss.Top,
-			styles.Header(m.search.View(), title),
+			styles.Header(m.search.View(), m.history.Title),
 			m.viewport.View(),
 			m.help.View(),
 		)
@@ -129,7 +191,7 @@ func (m Model) View() string {
 	case tableView:
 		return lipgloss.JoinVertical(
 			lipgloss.Top,
-			styles.SimpleHeader(m.search.View(), title),
+			styles.SimpleHeader(m.search.View(), m.history.Title),
 			m.table.View(),
 			m.help.View(),
 		)
@@ -144,6 +206,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds []tea.Cmd
 	)
 
+	if m.logsOpened {
+		if _, ok := msg.(logs.CloseMsg); ok {
+			m.logsOpened = false
+			return m, nil
+		}
+
+		m.logs, cmd = m.logs.Update(msg)
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -169,7 +241,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.help.Keys.TableView):
 			if !m.search.Focused() {
 				m.view = tableView
-				m.table = createTable(m.getFilteredMetrics(), m.width, m.height-lipgloss.Height(styles.SimpleHeader(m.search.View(), title))-lipgloss.Height(m.help.View()))
+				m.table = createTable(m.getFilteredMetrics(), m.history.Targets, m.width, m.height-lipgloss.Height(styles.SimpleHeader(m.search.View(), m.history.Title))-lipgloss.Height(m.help.View()))
 				m.viewport.SetContent(m.table.View())
 			}
 
@@ -179,6 +251,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewport.SetContent(getJsonContent(m.getFilteredMetrics()))
 			}
 
+		case key.Matches(msg, m.help.Keys.Logs):
+			if !m.search.Focused() {
+				m.logs = logs.New(logEntries(m.getLogsMetrics()), m.width, m.height)
+				m.logsOpened = true
+				return m, nil
+			}
+
 		case key.Matches(msg, m.help.Keys.Search):
 			if !m.search.Focused() && !m.help.FullViewOpened() {
 				m.search.Focus()
@@ -196,7 +275,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case listView:
 			m.viewport.SetContent(getListContent(m))
 		case tableView:
-			m.table = createTable(m.getFilteredMetrics(), m.width, m.height-lipgloss.Height(m.search.View())-lipgloss.Height(m.help.View()))
+			m.table = createTable(m.getFilteredMetrics(), m.history.Targets, m.width, m.height-lipgloss.Height(m.search.View())-lipgloss.Height(m.help.View()))
 		case jsonView:
 			m.viewport.SetContent(getJsonContent(m.getFilteredMetrics()))
 		}
@@ -222,10 +301,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func readAllMetrics() ([]data.TestBenchmark, error) {
+func readAllMetrics(file string) ([]data.TestBenchmark, error) {
 	var metrics []data.TestBenchmark
 
-	_bytes, err := os.ReadFile(constants.TestAnalyserFilePath)
+	_bytes, err := os.ReadFile(file)
 
 	if err != nil {
 		return nil, err
@@ -245,7 +324,12 @@ func (m Model) getFilteredMetrics() []data.TestBenchmark {
 
 	filtered := make([]data.TestBenchmark, 0)
 	for _, metric := range m.metrics {
-		if strings.Contains(metric.Project, m.search.Value()) || strings.Contains(metric.Description, m.search.Value()) {
+		if strings.Contains(metric.Target, m.search.Value()) ||
+			strings.Contains(metric.Project, m.search.Value()) ||
+			strings.Contains(metric.Description, m.search.Value()) ||
+			strings.Contains(metric.Cache.String(), m.search.Value()) ||
+			strings.Contains(metric.TargetOptions.String(), m.search.Value()) ||
+			strings.Contains(metric.SuiteRun.String(), m.search.Value()) {
 			filtered = append(filtered, metric)
 		}
 	}
@@ -253,6 +337,20 @@ func (m Model) getFilteredMetrics() []data.TestBenchmark {
 	return filtered
 }
 
+// getLogsMetrics returns the benchmark selected in the table view, or all the filtered benchmarks otherwise.
+func (m Model) getLogsMetrics() []data.TestBenchmark {
+	metrics := m.getFilteredMetrics()
+
+	if m.view == tableView {
+		if cursor := m.table.table.Cursor(); cursor >= 0 && cursor < len(metrics) {
+			return metrics[cursor : cursor+1]
+		}
+	}
+
+	return metrics
+}
+
+// Searching reports whether the key presses are captured by the search input or the log viewer.
 func (m Model) Searching() bool {
-	return m.search.Focused()
+	return m.search.Focused() || m.logsOpened
 }
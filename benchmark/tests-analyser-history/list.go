@@ -3,9 +3,12 @@ package tests_analyser_history
 import (
 	"fmt"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ionut-t/gonx/benchmark/budget"
+	data "github.com/ionut-t/gonx/benchmark/data"
 	"github.com/ionut-t/gonx/ui/styles"
 	"github.com/ionut-t/gonx/utils"
 	"github.com/ionut-t/gonx/workspace"
+	"slices"
 	"strings"
 )
 
@@ -19,17 +22,69 @@ func getListContent(model Model) string {
 	for i, bm := range metrics {
 		projectIcon := utils.Ternary(workspace.ProjectType(bm.Type) == workspace.ApplicationType, "💻", "📚")
 
-		content := lipgloss.JoinVertical(
-			lipgloss.Left,
+		lines := []string{
 			styles.NormalText.Render(fmt.Sprintf("%sRecorded on %s at %s", styles.IconStyle("🗓️"), bm.CreatedAt.Format("02/01/2006"), bm.CreatedAt.Format("15:04:05"))),
 			styles.NormalText.Render(fmt.Sprintf("%sDescription: %s", styles.IconStyle("📝"), utils.Ternary(bm.Description == "", "-", bm.Description))),
 			styles.NormalText.Render(fmt.Sprintf("%sProject: %s", styles.IconStyle(projectIcon), bm.Project)),
 			styles.NormalText.Render(fmt.Sprintf("%sProject type: %s", styles.IconStyle("📽️"), bm.Type)),
 			styles.Success.Render(fmt.Sprintf("%sTotal runs: %d", styles.IconStyle("🔄"), bm.TotalRuns)),
-			styles.Success.Render(fmt.Sprintf("%sMin: %.2fs", styles.IconStyle("🕒"), bm.Min)),
-			styles.Success.Render(fmt.Sprintf("%sMax: %.2fs", styles.IconStyle("🕒"), bm.Max)),
-			styles.Success.Render(fmt.Sprintf("%sAverage: %.2fs", styles.IconStyle("🕒"), bm.Average)),
-		)
+			styles.Info.Render(fmt.Sprintf("%sCache: %s", styles.IconStyle("💾"), bm.Cache)),
+			styles.Info.Render(fmt.Sprintf("%sOptions: %s", styles.IconStyle("🔩"), utils.Ternary(bm.TargetOptions.String() == "", "-", bm.TargetOptions.String()))),
+		}
+
+		// the summary of a benchmark whose runs all failed is empty, the failure is described below
+		if !data.AllRunsFailed(bm.TotalRuns, bm.FailedRuns) {
+			lines = append(
+				lines,
+				styles.Success.Render(fmt.Sprintf("%sMin: %.2fs", styles.IconStyle("🕒"), bm.Min)),
+				styles.Success.Render(fmt.Sprintf("%sMax: %.2fs", styles.IconStyle("🕒"), bm.Max)),
+				styles.Success.Render(fmt.Sprintf("%sAverage: %.2fs", styles.IconStyle("🕒"), bm.Average)),
+				styles.Success.Render(fmt.Sprintf("%sMedian: %.2fs", styles.IconStyle("🕒"), bm.Median)),
+				styles.Success.Render(fmt.Sprintf("%sp90: %.2fs, p95: %.2fs", styles.IconStyle("📈"), bm.P90, bm.P95)),
+				styles.Info.Render(fmt.Sprintf("%sStd dev: %.2fs (CV %.1f%%)", styles.IconStyle("📉"), bm.StdDev, bm.CV*100)),
+				styles.Info.Render(fmt.Sprintf("%s95%% CI: %.2fs – %.2fs", styles.IconStyle("🎯"), bm.CILow, bm.CIHigh)),
+			)
+		}
+
+		if model.history.Targets {
+			lines = slices.Insert(lines, 4, styles.NormalText.Render(fmt.Sprintf("%sTarget: %s", styles.IconStyle("🧩"), bm.Target)))
+		}
+
+		content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+		if bm.Workers > 1 {
+			content += "\n" + styles.Info.Render(fmt.Sprintf("%sWorkers: %d projects benchmarked in parallel", styles.IconStyle("👷"), bm.Workers))
+		}
+
+		if bm.WarmupRuns > 0 {
+			content += "\n" + styles.Info.Render(fmt.Sprintf("%sWarm-up runs: %d, excluded from the stats", styles.IconStyle("🔥"), bm.WarmupRuns))
+		}
+
+		if bm.OutlierPolicy != "" && bm.OutlierPolicy != data.OutliersNone {
+			content += "\n" + styles.Info.Render(fmt.Sprintf("%sOutliers rejected (%s): %d", styles.IconStyle("🧹"), strings.ToUpper(string(bm.OutlierPolicy)), bm.Outliers))
+		}
+
+		if bm.FailedRuns > 0 {
+			content += "\n" + styles.Error.Render(fmt.Sprintf(
+				"%s%d of %d runs failed (success rate %.0f%%)",
+				styles.IconStyle("❌"),
+				bm.FailedRuns,
+				bm.TotalRuns,
+				bm.SuccessRate*100,
+			))
+		}
+
+		if bm.Cancelled {
+			content += "\n" + styles.Warning.Render(fmt.Sprintf("%sCancelled, partial results", styles.IconStyle("⚠️")))
+		}
+
+		if bm.Suite != "" {
+			content += "\n" + styles.Info.Render(fmt.Sprintf("%sSuite: %s", styles.IconStyle("🧪"), bm.SuiteRun))
+		}
+
+		if len(bm.BudgetChecks) > 0 {
+			content += "\n" + budget.Render(bm.BudgetChecks)
+		}
 
 		if i < len(metrics)-1 {
 			content += "\n\n" + border + "\n"
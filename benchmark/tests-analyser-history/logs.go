@@ -0,0 +1,18 @@
+package tests_analyser_history
+
+import (
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/ui/logs"
+)
+
+// logEntries returns the logs of the runs of the given benchmarks.
+func logEntries(metrics []data.TestBenchmark) []logs.Entry {
+	var entries []logs.Entry
+
+	for _, bm := range metrics {
+		entries = append(entries, logs.Runs(fmt.Sprintf("%s · %s", bm.Project, bm.CreatedAt.Format("02/01/06 15:04")), bm.Runs)...)
+	}
+
+	return entries
+}
@@ -6,6 +6,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	data "github.com/ionut-t/gonx/benchmark/data"
 	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/utils"
+	"slices"
+	"strings"
 )
 
 var tableStyles = styles.DefaultTableStyles()
@@ -41,31 +44,56 @@ func (m tableModel) View() string {
 	return tableStyles.Base.Render(m.table.View())
 }
 
-func createTable(metrics []data.TestBenchmark, width, height int) tableModel {
-	colWidth := (width - 65) / 4
+func createTable(metrics []data.TestBenchmark, targets bool, width, height int) tableModel {
+	// the confidence interval, the runs, the setup, the budgets and the target have a fixed width, the other columns share the remaining space
+	colWidth := (width - utils.Ternary(targets, 131, 119)) / 6
 
 	columns := []table.Column{
 		{Title: "#", Width: 3},
-		{Title: "App", Width: 25},
-		{Title: "Created", Width: 20},
+		{Title: "App", Width: 18},
+		{Title: "Created", Width: 15},
 		{Title: "Min", Width: colWidth},
 		{Title: "Max", Width: colWidth},
-		{Title: "Average", Width: colWidth},
-		{Title: "Total runs", Width: colWidth},
+		{Title: "Avg", Width: colWidth},
+		{Title: "Median", Width: colWidth},
+		{Title: "p95", Width: colWidth},
+		{Title: "CV", Width: colWidth},
+		{Title: "95% CI", Width: 15},
+		{Title: "Total runs", Width: 12},
+		{Title: "Setup", Width: 16},
+		{Title: "Perf budget", Width: 12},
+	}
+
+	if targets {
+		columns = slices.Insert(columns, 2, table.Column{Title: "Target", Width: 12})
 	}
 
 	var rows []table.Row
 
 	for idx, bm := range metrics {
-		rows = append(rows, table.Row{
+		failed := data.AllRunsFailed(bm.TotalRuns, bm.FailedRuns)
+
+		row := table.Row{
 			fmt.Sprintf("%d", idx+1),
-			bm.Project,
+			utils.Ternary(bm.FailedRuns > 0, "✗ "+bm.Project, bm.Project),
 			bm.CreatedAt.Format("02/01/06 15:04"),
-			fmt.Sprintf("%.2fs", bm.Min),
-			fmt.Sprintf("%.2fs", bm.Max),
-			fmt.Sprintf("%.2fs", bm.Average),
-			fmt.Sprintf("%d", bm.TotalRuns),
-		})
+			stat(failed, "%.2fs", bm.Min),
+			stat(failed, "%.2fs", bm.Max),
+			stat(failed, "%.2fs", bm.Average),
+			stat(failed, "%.2fs", bm.Median),
+			stat(failed, "%.2fs", bm.P95),
+			stat(failed, "%.1f%%", bm.CV*100),
+			stat(failed, "%.2f–%.2fs", bm.CILow, bm.CIHigh),
+			totalRuns(bm),
+			setup(bm),
+			bm.BudgetChecks.Label(),
+		}
+
+		if targets {
+			row = slices.Insert(row, 2, bm.Target)
+		}
+
+		rows = append(rows, row)
 	}
 
 	newTable := table.New(
@@ -84,3 +112,40 @@ func createTable(metrics []data.TestBenchmark, width, height int) tableModel {
 
 	return tableModel{newTable}
 }
+
+// stat formats a value of the summary, a dash when the runs all failed and the summary is empty.
+func stat(failed bool, format string, values ...any) string {
+	if failed {
+		return "-"
+	}
+
+	return fmt.Sprintf(format, values...)
+}
+
+// totalRuns describes the number of runs, along with the failures and the cancellation.
+func totalRuns(bm data.TestBenchmark) string {
+	var notes []string
+
+	if bm.FailedRuns > 0 {
+		notes = append(notes, fmt.Sprintf("%d failed", bm.FailedRuns))
+	}
+
+	if bm.Cancelled {
+		notes = append(notes, "cancelled")
+	}
+
+	if len(notes) == 0 {
+		return fmt.Sprintf("%d", bm.TotalRuns)
+	}
+
+	return fmt.Sprintf("%d (%s)", bm.TotalRuns, strings.Join(notes, ", "))
+}
+
+// setup describes the cache mode and the parallelism the benchmark was recorded with, e.g. "warm +daemon ×4".
+func setup(bm data.TestBenchmark) string {
+	if bm.Workers > 1 {
+		return fmt.Sprintf("%s ×%d", bm.Cache.Short(), bm.Workers)
+	}
+
+	return bm.Cache.Short()
+}
@@ -1,181 +1,99 @@
 package tests_analyser
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/google/uuid"
+	"github.com/ionut-t/gonx/benchmark/budget"
 	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/benchmark/stats"
 	"github.com/ionut-t/gonx/internal/constants"
-	"github.com/ionut-t/gonx/utils"
 	"github.com/ionut-t/gonx/workspace"
-	"math"
-	"os"
-	"os/exec"
 	"time"
 )
 
-type TestBenchmark data.TestBenchmark
-
-func (b *TestBenchmark) WriteStats() error {
-	b.CreatedAt = time.Now()
-
-	benchmark, err := utils.ToJsonString(b)
-
-	if err != nil {
-		return err
-	}
-
-	var results []json.RawMessage
-	currentValue, err := os.ReadFile(constants.TestAnalyserFilePath)
-
-	if err == nil && len(currentValue) > 0 {
-		if err := json.Unmarshal(currentValue, &results); err != nil {
-			return err
-		}
-	}
-
-	results = append([]json.RawMessage{json.RawMessage(benchmark)}, results...)
-
-	content, err := json.MarshalIndent(results, "", "  ")
-
-	if err != nil {
-		return err
-	}
-
-	err = os.MkdirAll(constants.BenchmarkFolderPath, 0755)
-
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(constants.TestAnalyserFilePath, content, 0644)
+// Analyser is the target benchmarked by the analyser, along with the history its benchmarks are written to.
+type Analyser struct {
+	Target string
+	File   string
+	// Budgets are the performance budgets checked against the benchmarks, none are checked when empty.
+	Budgets budget.Analyser
 }
 
-func startBenchmark(projects []workspace.Project, description string, count int) tea.Cmd {
-	/// Calculate total number of processes:
-	// - Initial TotalProcessesMsg (1)
-	// - For each app:
-	//   - For each run (count times):
-	//     - NxCacheResetStartMsg (1)
-	//     - TestsStartMsg + TestsCompleteMsg/TestsFailedMsg (2)
-	//   - After all runs:
-	//     - WriteStatsStartMsg + WriteStatsCompleteMsg/WriteStatsFailedMsg (2)
-	totalProcesses := 1 + len(projects)*(3*count+2)
-
-	// Create channel for build results
-	results := make(chan tea.Msg, totalProcesses)
-
-	go func() {
-		benchmarkStartTime := time.Now()
+// Build benchmarks the build target of the apps.
+var Build = Analyser{Target: "build", File: constants.BuildAnalyserFilePath, Budgets: budget.BuildAnalyser}
 
-		defer close(results)
+// Lint benchmarks the lint target of the projects.
+var Lint = Analyser{Target: "lint", File: constants.LintAnalyserFilePath, Budgets: budget.LintAnalyser}
 
-		results <- TotalProcessesMsg(totalProcesses - 1) // -1 for this message
+// Tests benchmarks the test target of the projects.
+var Tests = Analyser{Target: "test", File: constants.TestAnalyserFilePath, Budgets: budget.TestAnalyser}
 
-		for _, project := range projects {
-			durations := make([]float64, count)
-
-			benchmark := TestBenchmark{
-				ID:          uuid.New(),
-				Project:     project.GetName(),
-				Type:        project.GetType(),
-				Description: description,
-			}
-
-			for i := 0; i < count; i++ {
-				results <- NxCacheResetStartMsg{
-					StartTime: time.Now(),
-				}
-
-				// First, run nx reset for the whole workspace
-				cmdReset := exec.Command("nx", "reset")
-
-				if err := cmdReset.Run(); err != nil {
-					// If reset fails, send failed messages for all projects
-					for _, app := range projects {
-						results <- TestsFailedMsg{
-							Project: app,
-							Error:   fmt.Errorf("nx reset failed: %v", err),
-						}
-					}
-					return
-				}
-
-				// Send start message
-				results <- TestsStartMsg{
-					Project:    project,
-					StartTime:  time.Now(),
-					CurrentRun: i + 1,
-					TotalRuns:  count,
-				}
-
-				startTime := time.Now()
+// Target benchmarks any other target of the projects, e.g. e2e or typecheck, checked against the budgets of that target.
+func Target(target string) Analyser {
+	return Analyser{Target: target, File: constants.TargetAnalyserFilePath, Budgets: budget.TargetAnalyser}
+}
 
-				// Run lint
-				cmdTest := exec.Command("nx", "test", project.GetName())
+type TestBenchmark data.TestBenchmark
 
-				if err := cmdTest.Run(); err != nil {
-					results <- TestsFailedMsg{
-						Project:  project,
-						RunIndex: i,
-						EndTime:  time.Now(),
-						Error:    fmt.Errorf("test analyser failed: %v", err),
-					}
-					continue // Continue with next run even if one fails
-				}
+func (b *TestBenchmark) WriteStats(file string) error {
+	b.CreatedAt = time.Now()
 
-				duration := time.Since(startTime).Seconds()
+	return data.Prepend(file, b)
+}
 
-				durations[i] = duration
+// Run starts the benchmark of the target of the analyser for the given projects and returns its event stream.
+func Run(ctx context.Context, analyser Analyser, projects []workspace.Project, settings runner.Settings) <-chan runner.Event {
+	// the budgets are read once for all the projects, which are still benchmarked when they can't be read
+	var (
+		budgets   []budget.Budget
+		budgetErr error
+	)
+	if analyser.Budgets != "" {
+		budgets, budgetErr = budget.Budgets()
+	}
 
-				results <- TestsCompleteMsg{
-					Project:  project,
-					Duration: duration,
-				}
-			}
+	return runner.Start(ctx, runner.Options[TestBenchmark]{
+		Settings: settings,
+		Projects: projects,
+		Target:   analyser.Target,
+		Finish: func(result runner.Result) (TestBenchmark, error) {
+			benchmark := newTestBenchmark(analyser.Target, result, settings)
+			benchmark.BudgetChecks = budget.CheckDurations(budgets, analyser.Budgets, analyser.Target, result.Project, benchmark.Summary)
 
-			var sum, minDuration, maxDuration float64
-			minDuration = durations[0]
-			for _, duration := range durations {
-				sum += duration
-				minDuration = math.Min(minDuration, duration)
-				maxDuration = math.Max(maxDuration, duration)
+			if err := benchmark.WriteStats(analyser.File); err != nil {
+				return benchmark, fmt.Errorf("failed to write stats: %v", err)
 			}
 
-			benchmark.Duration = time.Since(benchmarkStartTime).Seconds()
-			benchmark.Min = minDuration
-			benchmark.Max = maxDuration
-			benchmark.Average = sum / float64(len(durations))
-			benchmark.TotalRuns = count
-
-			results <- WriteStatsStartMsg{Project: project, StartTime: time.Now()}
-
-			err := benchmark.WriteStats()
-			if err != nil {
-				results <- WriteStatsFailedMsg{
-					Project: project,
-					Time:    time.Now(),
-					Error:   fmt.Errorf("failed to write stats: %v", err),
-				}
-				continue
+			if budgetErr != nil {
+				return benchmark, fmt.Errorf("failed to check the performance budgets: %v", budgetErr)
 			}
 
-			results <- WriteStatsCompleteMsg{
-				Project:   project,
-				Time:      time.Now(),
-				Benchmark: benchmark,
-			}
-		}
-	}()
+			return benchmark, nil
+		},
+	})
+}
 
-	// Create commands to read all expected messages
-	var cmds []tea.Cmd
-	for i := 0; i < totalProcesses; i++ {
-		cmds = append(cmds, func() tea.Msg {
-			return <-results
-		})
+func newTestBenchmark(target string, result runner.Result, settings runner.Settings) TestBenchmark {
+	return TestBenchmark{
+		ID:            result.ID,
+		Target:        target,
+		Project:       result.Project.GetName(),
+		Type:          result.Project.GetType(),
+		Description:   settings.Description,
+		Duration:      time.Since(result.StartTime).Seconds(),
+		Summary:       stats.Summarise(result.Durations()),
+		Cache:         settings.Cache,
+		TargetOptions: settings.TargetOptions,
+		SuiteRun:      settings.Suite,
+		Workers:       max(1, settings.Workers),
+		TotalRuns:     result.TotalRuns(),
+		FailedRuns:    result.FailedRuns(),
+		SuccessRate:   result.SuccessRate(),
+		WarmupRuns:    settings.WarmupRuns,
+		OutlierPolicy: settings.Outliers,
+		Outliers:      result.Outliers(),
+		Cancelled:     result.Cancelled,
+		Runs:          result.Records(),
 	}
-
-	return tea.Batch(cmds...)
 }
@@ -1,18 +1,25 @@
 package tests_analyser
 
 import (
+	"context"
 	"fmt"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ionut-t/gonx/benchmark/budget"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
 	form "github.com/ionut-t/gonx/benchmark/shared-form"
 	"github.com/ionut-t/gonx/internal/keymap"
 	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/logs"
 	"github.com/ionut-t/gonx/ui/styles"
 	"github.com/ionut-t/gonx/ui/suspense"
+	"github.com/ionut-t/gonx/ui/tail"
 	"github.com/ionut-t/gonx/ui/viewport"
+	"github.com/ionut-t/gonx/ui/workers"
 	"github.com/ionut-t/gonx/utils"
 	"github.com/ionut-t/gonx/workspace"
 	"strings"
@@ -23,43 +30,63 @@ const resultTitle = "📊 Benchmark results"
 
 const padding = 2
 
+var buildHint = fmt.Sprintf(
+	"Press %s to cancel the benchmark or %s to toggle the output.",
+	keymap.Cancel.Help().Key,
+	keymap.Output.Help().Key,
+)
+
+var logsHint = fmt.Sprintf("Press %s to browse the logs of the runs.", keymap.Logs.Help().Key)
+
 type view int
 
 const (
 	formView view = iota
 	buildView
 	resultsView
+	logsView
 )
 
 type Model struct {
-	view     view
-	projects []workspace.Project
-	form     form.Model
-	viewport viewport.Model
-	suspense suspense.Model
-	progress progress.Model
+	view          view
+	analyser      Analyser
+	projects      []workspace.Project
+	configuration string
+	form          form.Model
+	logs          logs.Model
+	viewport      viewport.Model
+	suspense      suspense.Model
+	progress      progress.Model
+	output        tail.Model
+	workers       workers.Model
 
 	width  int
 	height int
 
-	count          int
-	completed      int
-	totalProcesses int
-	results        []TestBenchmark
+	ctx        context.Context
+	cancel     context.CancelFunc
+	cancelled  bool
+	events     <-chan runner.Event
+	completed  int
+	totalSteps int
+	runLogs    []logs.Entry
+	results    []TestBenchmark
 }
 
-func New(projects []workspace.Project, width, height int) Model {
+func New(ctx context.Context, analyser Analyser, projects []workspace.Project, configuration string, width, height int) Model {
 	return Model{
-		projects: projects,
-		width:    width,
-		height:   height,
-		form:     form.New(),
+		analyser:      analyser,
+		configuration: configuration,
+		ctx:           ctx,
+		projects:      projects,
+		width:         width,
+		height:        height,
+		form:          form.New(),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
-	//return m.form.Init()
+	return m.form.Init()
 }
 
 func (m Model) View() string {
@@ -68,18 +95,18 @@ func (m Model) View() string {
 		return lipgloss.NewStyle().Padding(1, 1).Render(m.form.View())
 
 	case buildView:
-		return lipgloss.JoinVertical(
-			lipgloss.Left,
-			lipgloss.NewStyle().Padding(1, 1).Render(m.suspense.View()),
-			lipgloss.NewStyle().Padding(0, 1).Render(m.progress.View()),
-		)
+		return m.output.Under(m.statusView())
 
 	case resultsView:
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
 			styles.Header("", resultTitle),
 			m.viewport.View(),
+			lipgloss.NewStyle().Padding(0, 1).Render(styles.DimText.Render(logsHint)),
 		)
+
+	case logsView:
+		return m.logs.View()
 	}
 
 	return ""
@@ -91,77 +118,153 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds []tea.Cmd
 	)
 
+	if m.view == logsView {
+		if _, ok := msg.(logs.CloseMsg); ok {
+			m.view = resultsView
+			return m, nil
+		}
+
+		m.logs, cmd = m.logs.Update(msg)
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.progress.Width = m.width - padding*2
+		m.output.SetSize(m.statusView(), m.width, m.height)
 
 	case form.FormMsg:
 		m.view = buildView
-		m.count = msg.Count
 
 		return m, messages.Dispatch(StartMsg{
-			StartTime:   time.Now(),
-			Projects:    m.projects,
-			Count:       msg.Count,
-			Description: msg.Description,
+			StartTime: time.Now(),
+			Projects:  m.projects,
+			Settings: runner.Settings{
+				Description: msg.Description,
+				Runs:        msg.Count,
+				KeepPartial: msg.KeepPartial,
+				WarmupRuns:  msg.WarmupRuns,
+				Outliers:    msg.Outliers,
+				Cache:       msg.Cache,
+				TargetOptions: data.TargetOptions{
+					Configuration: m.configuration,
+					Args:          msg.Args,
+					Env:           msg.Env,
+				},
+				Workers: msg.Workers,
+			},
 		})
 
 	case StartMsg:
 		m.completed = 0
+		// every warm-up and measured run of every project, plus writing the stats of each project
+		m.totalSteps = len(msg.Projects) * (msg.Settings.WarmupRuns + msg.Settings.Runs + 1)
 		m.results = make([]TestBenchmark, 0)
-		m.suspense = suspense.New("Starting tests benchmark", true)
+		m.suspense = suspense.New(fmt.Sprintf("Starting %s benchmark", m.analyser.Target), true)
 		m.progress = progress.New(progress.WithDefaultGradient())
 		m.progress.Width = m.width - padding*2
 		m.progress.PercentageStyle = styles.Primary
+		m.workers = workers.New(msg.Settings.Workers)
+		// the height of the output depends on the status of the workers
+		m.output = tail.New(m.statusView(), m.width, m.height)
+		if m.workers.Parallel() {
+			m.suspense.Message = m.workers.Message()
+			m.output.Interleave(fmt.Sprintf("nx %s output of %d workers", m.analyser.Target, msg.Settings.Workers))
+		}
+		m.cancelled = false
+		m.runLogs = nil
+
+		var ctx context.Context
+		ctx, m.cancel = context.WithCancel(m.ctx)
+		m.events = Run(ctx, m.analyser, msg.Projects, msg.Settings)
 
 		return m, tea.Batch(
-			startBenchmark(msg.Projects, msg.Description, msg.Count),
+			m.listen(),
 			m.suspense.Spinner.Tick,
 			m.progress.SetPercent(0.0),
 		)
 
-	case TotalProcessesMsg:
-		m.totalProcesses = int(msg)
-
-	case NxCacheResetStartMsg:
+	case runner.ResetStarted:
 		m.suspense.Message = "Resetting the Nx cache and stopping the daemon"
-		m.suspense.Loading = true
-		return m, m.suspense.Spinner.Tick
+		return m, m.listen()
+
+	case runner.ResetFinished:
+		if msg.Error != nil {
+			m.suspense.Message = msg.Error.Error()
+		} else if m.workers.Parallel() {
+			m.suspense.Message = m.workers.Message()
+		}
+		return m, m.listen()
 
-	case TestsStartMsg:
-		m.suspense.Message = fmt.Sprintf("Running tests for %s %s (%d/%d)",
+	case runner.RunStarted:
+		message := fmt.Sprintf("Running %s on %s %s (%s)",
+			m.analyser.Target,
 			styles.Primary.Bold(true).Render(msg.Project.GetName()),
 			msg.Project.GetType(),
-			msg.CurrentRun,
-			msg.TotalRuns,
+			msg.Label(),
 		)
-		return m, m.progress.IncrPercent(m.getProgressIncrement())
+		if m.workers.Parallel() {
+			m.workers.Set(msg.Worker, message)
+			return m, m.listen()
+		}
 
-	case TestsCompleteMsg:
-		m.completed++
-		return m, m.progress.IncrPercent(m.getProgressIncrement())
+		m.suspense.Message = message
+		m.output.Reset(fmt.Sprintf("nx %s %s (%s)", m.analyser.Target, msg.Project.GetName(), msg.Label()))
+		return m, m.listen()
+
+	case runner.Output:
+		m.output.Push(msg.Project.GetName(), msg.Lines...)
+		return m, m.listen()
 
-	case TestsFailedMsg:
+	case runner.RunFinished:
 		m.completed++
-		m.suspense.Message = msg.Error.Error()
-		return m.handleBenchmarkBuild()
+		m.workers.Idle(msg.Worker)
+		if msg.Error != nil {
+			m.suspense.Message = msg.Error.Error()
+		}
+		if msg.Log != "" {
+			m.runLogs = append(m.runLogs, logs.Entry{
+				Title:  fmt.Sprintf("%s (%s)", msg.Project.GetName(), msg.Label()),
+				Path:   msg.Log,
+				Failed: msg.Status == data.RunFailed,
+			})
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
 
-	case WriteStatsStartMsg:
-		m.suspense.Message = fmt.Sprintf("Writing stats for %s %s",
+	case runner.StatsStarted:
+		message := fmt.Sprintf("Writing stats for %s %s...",
 			styles.Primary.Bold(true).Render(msg.Project.GetName()),
 			msg.Project.GetType(),
 		)
-		return m, m.progress.IncrPercent(m.getProgressIncrement())
+		if m.workers.Parallel() {
+			m.workers.Set(msg.Worker, message)
+			return m, m.listen()
+		}
+
+		m.suspense.Message = message
+		return m, m.listen()
 
-	case WriteStatsCompleteMsg:
-		m.results = append(m.results, msg.Benchmark)
-		return m.handleBenchmarkBuild()
+	case runner.StatsWritten[TestBenchmark]:
+		m.completed++
+		m.workers.Idle(msg.Worker)
+		if msg.Error != nil {
+			m.suspense.Message = msg.Error.Error()
+		} else {
+			m.results = append(m.results, msg.Benchmark)
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
 
-	case WriteStatsFailedMsg:
-		m.suspense.Message = msg.Error.Error()
-		return m.handleBenchmarkBuild()
+	case CompleteMsg:
+		m.cancel()
+		return m, tea.Sequence(
+			m.progress.SetPercent(1.0),
+			// wait for the progress bar to finish animating
+			tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
+				return DoneMsg{}
+			}),
+		)
 
 	case DoneMsg:
 		renderBenchmarkResults(&m)
@@ -185,6 +288,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		switch {
+		case key.Matches(msg, keymap.Cancel):
+			if m.view == buildView && m.cancel != nil && !m.cancelled {
+				m.cancel()
+				m.cancelled = true
+				m.suspense.Message = "Cancelling the benchmark..."
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Output):
+			if m.view == buildView {
+				m.output.Toggle()
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Logs):
+			if m.view == resultsView {
+				m.logs = logs.New(m.runLogs, m.width, m.height)
+				m.view = logsView
+				return m, nil
+			}
+
 		case key.Matches(msg, keymap.Back):
 			if m.view != buildView {
 				return m, messages.Dispatch(messages.NavigateToViewMsg(
@@ -220,22 +344,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func (m Model) handleBenchmarkBuild() (Model, tea.Cmd) {
-	if m.completed == (len(m.projects) * m.count) {
-		return m, tea.Sequence(
-			m.progress.SetPercent(1.0),
-			// wait for the progress bar to finish animating
-			tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
-				return DoneMsg{}
-			}),
-		)
+func (m Model) statusView() string {
+	views := []string{lipgloss.NewStyle().Padding(1, 1).Render(m.suspense.View())}
+
+	// the status of every worker of a parallel benchmark
+	if m.workers.Parallel() {
+		views = append(views, lipgloss.NewStyle().Padding(0, 1, 1).Render(m.workers.View()))
 	}
 
-	return m, m.progress.IncrPercent(m.getProgressIncrement())
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		append(
+			views,
+			lipgloss.NewStyle().Padding(0, 1).Render(m.progress.View()),
+			lipgloss.NewStyle().Padding(1, 1).Render(styles.DimText.Render(buildHint)),
+		)...,
+	)
+}
+
+func (m Model) listen() tea.Cmd {
+	return messages.Listen(m.events, CompleteMsg{})
 }
 
-func (m Model) getProgressIncrement() float64 {
-	return 1.0 / float64(m.totalProcesses)
+func (m Model) progressPercent() float64 {
+	return float64(m.completed) / float64(m.totalSteps)
 }
 
 func renderBenchmarkResults(m *Model) {
@@ -249,7 +381,7 @@ func renderBenchmarkResults(m *Model) {
 		content := lipgloss.JoinVertical(
 			lipgloss.Left,
 			border,
-			fmt.Sprintf("Stats for %s %s:", styles.Primary.Bold(true).Render(bm.Project), bm.Type),
+			fmt.Sprintf("Stats of %s for %s %s:", m.analyser.Target, styles.Primary.Bold(true).Render(bm.Project), bm.Type),
 			border,
 			renderStats(bm),
 			border,
@@ -262,6 +394,10 @@ func renderBenchmarkResults(m *Model) {
 		contents = append(contents, content)
 	}
 
+	if m.cancelled {
+		contents = append([]string{styles.Warning.Render(cancelledMessage(len(results))) + "\n"}, contents...)
+	}
+
 	output := lipgloss.NewStyle().
 		Padding(0, 4).
 		Render(lipgloss.JoinVertical(
@@ -279,15 +415,87 @@ func renderBenchmarkResults(m *Model) {
 }
 
 func renderStats(bm TestBenchmark) string {
-	return lipgloss.JoinVertical(
+	if data.AllRunsFailed(bm.TotalRuns, bm.FailedRuns) {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.Error.Render(fmt.Sprintf("%sAll %d runs failed, no stats were recorded", styles.IconStyle("❌"), bm.TotalRuns)),
+			styles.Info.Render(fmt.Sprintf("%sCache: %s", styles.IconStyle("💾"), bm.Cache)),
+		)
+	}
+
+	stats := lipgloss.JoinVertical(
 		lipgloss.Left,
 		styles.Success.Render(fmt.Sprintf("%sMin: %.2fs", styles.IconStyle("🕒"), bm.Min)),
 		styles.Success.Render(fmt.Sprintf("%sMax: %.2fs", styles.IconStyle("🕒"), bm.Max)),
 		styles.Success.Render(fmt.Sprintf("%sAverage: %.2fs", styles.IconStyle("🕒"), bm.Average)),
+		styles.Success.Render(fmt.Sprintf("%sMedian: %.2fs", styles.IconStyle("🕒"), bm.Median)),
+		styles.Success.Render(fmt.Sprintf("%sp90: %.2fs, p95: %.2fs", styles.IconStyle("📈"), bm.P90, bm.P95)),
+		styles.Info.Render(fmt.Sprintf("%sStd dev: %.2fs (CV %.1f%%)", styles.IconStyle("📉"), bm.StdDev, bm.CV*100)),
+		styles.Info.Render(fmt.Sprintf("%s95%% CI: %.2fs – %.2fs", styles.IconStyle("🎯"), bm.CILow, bm.CIHigh)),
 		styles.Success.Render(fmt.Sprintf("%sTotal runs: %d", styles.IconStyle("🔄"), bm.TotalRuns)),
+		styles.Info.Render(fmt.Sprintf("%sCache: %s", styles.IconStyle("💾"), bm.Cache)),
 	)
+
+	if options := bm.TargetOptions.String(); options != "" {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sOptions: %s", styles.IconStyle("🔩"), options)),
+		)
+	}
+
+	if bm.Workers > 1 {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sWorkers: %d projects benchmarked in parallel", styles.IconStyle("👷"), bm.Workers)),
+		)
+	}
+
+	if bm.WarmupRuns > 0 {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sWarm-up runs: %d, excluded from the stats", styles.IconStyle("🔥"), bm.WarmupRuns)),
+		)
+	}
+
+	if bm.OutlierPolicy != "" && bm.OutlierPolicy != data.OutliersNone {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sOutliers rejected (%s): %d", styles.IconStyle("🧹"), strings.ToUpper(string(bm.OutlierPolicy)), bm.Outliers)),
+		)
+	}
+
+	if bm.FailedRuns > 0 {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Error.Render(fmt.Sprintf(
+				"%sFailed runs: %d, the stats only include the successful runs (success rate %.0f%%)",
+				styles.IconStyle("❌"),
+				bm.FailedRuns,
+				bm.SuccessRate*100,
+			)),
+		)
+	}
+
+	if len(bm.BudgetChecks) > 0 {
+		stats = lipgloss.JoinVertical(lipgloss.Left, stats, budget.Render(bm.BudgetChecks))
+	}
+
+	return stats
+}
+
+func cancelledMessage(results int) string {
+	if results == 0 {
+		return "The benchmark was cancelled, no results were recorded."
+	}
+
+	return "The benchmark was cancelled, the results below are partial."
 }
 
 func (m Model) viewportHeight() int {
-	return m.height - lipgloss.Height(styles.Header(resultTitle))
+	return m.height - lipgloss.Height(styles.Header(resultTitle)) - lipgloss.Height(logsHint)
 }
@@ -0,0 +1,17 @@
+package workspace_analyser
+
+import (
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"time"
+)
+
+type StartMsg struct {
+	Operation Operation
+	Settings  runner.Settings
+	StartTime time.Time
+}
+
+// CompleteMsg is sent once the event stream of the benchmark is closed.
+type CompleteMsg struct{}
+
+type DoneMsg struct{}
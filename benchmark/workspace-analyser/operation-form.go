@@ -0,0 +1,127 @@
+package workspace_analyser
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/ui/styles"
+	"slices"
+	"strings"
+)
+
+// operationForm asks for the nx command to benchmark before the settings of the benchmark.
+type operationForm struct {
+	form *huh.Form
+	// values are bound to the fields, so that the hidden groups follow the selected command
+	values   *operationValues
+	targets  []string
+	projects []string
+	// selected are the projects run-many starts with, e.g. everything downstream of a project
+	selected []string
+}
+
+type operationValues struct {
+	kind     string
+	targets  []string
+	projects []string
+	base     string
+}
+
+func newOperationForm(targets, projects, selected []string) operationForm {
+	values := &operationValues{kind: string(data.OperationRunMany), projects: slices.Clone(selected)}
+
+	kind := huh.NewSelect[string]().
+		Key("kind").
+		Value(&values.kind).
+		Title("Which nx command should be benchmarked?").
+		Options(
+			huh.NewOption("nx run-many, for the given projects or all of them", string(data.OperationRunMany)),
+			huh.NewOption("nx affected, for the projects changed since a base", string(data.OperationAffected)),
+		)
+
+	targetsSelect := huh.NewMultiSelect[string]().
+		Key("targets").
+		Value(&values.targets).
+		Title("Which targets should run?").
+		Options(huh.NewOptions(targets...)...).
+		Validate(func(selected []string) error {
+			if len(selected) == 0 {
+				return fmt.Errorf("select at least one target")
+			}
+
+			return nil
+		})
+
+	projectsSelect := huh.NewMultiSelect[string]().
+		Key("projects").
+		Value(&values.projects).
+		Title("Which projects should run-many run? None means all of them").
+		Options(huh.NewOptions(projects...)...)
+
+	base := huh.NewInput().
+		Key("base").
+		Value(&values.base).
+		Title("Base of the affected projects, e.g. main or HEAD~1").
+		Placeholder("the default base of the workspace")
+
+	form := huh.NewForm(
+		huh.NewGroup(kind, targetsSelect),
+		huh.NewGroup(projectsSelect).WithHideFunc(func() bool {
+			return values.kind != string(data.OperationRunMany)
+		}),
+		huh.NewGroup(base).WithHideFunc(func() bool {
+			return values.kind != string(data.OperationAffected)
+		}),
+	).WithTheme(huh.ThemeCatppuccin()).WithShowHelp(false)
+
+	form.WithKeyMap(&operationKeyMap)
+
+	return operationForm{form: form, values: values, targets: targets, projects: projects, selected: selected}
+}
+
+func (m operationForm) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+func (m operationForm) Update(msg tea.Msg) (operationForm, tea.Cmd) {
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	return m, cmd
+}
+
+func (m operationForm) View() string {
+	return m.form.View() + "\n" + styles.DimText.Render("space toggle • enter next • shift+tab previous • esc back")
+}
+
+func (m operationForm) completed() bool {
+	return m.form.State == huh.StateCompleted
+}
+
+// operation returns the nx command chosen in the completed form.
+func (m operationForm) operation() Operation {
+	operation := Operation{
+		Kind:    data.WorkspaceOperation(m.values.kind),
+		Targets: m.values.targets,
+	}
+
+	switch operation.Kind {
+	case data.OperationRunMany:
+		operation.Projects = m.values.projects
+	case data.OperationAffected:
+		operation.Base = strings.TrimSpace(m.values.base)
+	}
+
+	return operation
+}
+
+var operationKeyMap = func() huh.KeyMap {
+	keyMap := *huh.NewDefaultKeyMap()
+	keyMap.Quit = key.NewBinding(key.WithKeys("ctrl+c", "ctrl+q"))
+
+	return keyMap
+}()
@@ -0,0 +1,189 @@
+package workspace_analyser
+
+import (
+	"context"
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/benchmark/stats"
+	"github.com/ionut-t/gonx/internal/constants"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// operationType is the type of the pseudo project standing for the whole workspace in the runner.
+const operationType workspace.ProjectType = "workspace"
+
+// Operation describes the nx run-many or nx affected command benchmarked as a single unit.
+// It is run as a project of its own, so that it gets the same runs, stats and logs as the projects.
+type Operation struct {
+	Kind    data.WorkspaceOperation
+	Targets []string
+	// Projects restricts run-many to the given projects, all the projects being used when empty.
+	Projects []string
+	// Base is the git reference nx affected compares the working tree against, the default base of nx when empty.
+	Base string
+	// Parallel is passed to nx as --parallel when greater than 0.
+	Parallel int
+}
+
+func (o Operation) GetName() string {
+	return data.WorkspaceBenchmark{Operation: o.Kind, Targets: o.Targets, Projects: o.Projects, Base: o.Base}.Command()
+}
+
+func (o Operation) GetType() workspace.ProjectType {
+	return operationType
+}
+
+func (o Operation) GetRoot() string {
+	return "."
+}
+
+func (o Operation) GetTags() []string {
+	return nil
+}
+
+func (o Operation) GetTargets() []string {
+	return o.Targets
+}
+
+func (o Operation) GetTarget(string) (workspace.Target, bool) {
+	return workspace.Target{}, false
+}
+
+func (o Operation) GetConfigurations(string) []string {
+	return nil
+}
+
+func (o Operation) GetDependencies() []string {
+	return nil
+}
+
+// args returns the arguments of the nx command, the static output style keeping the output of the tasks readable in the logs.
+func (o Operation) args() []string {
+	args := []string{string(o.Kind), "--targets=" + strings.Join(o.Targets, ","), "--output-style=static"}
+
+	if o.Kind == data.OperationRunMany && len(o.Projects) > 0 {
+		args = append(args, "--projects="+strings.Join(o.Projects, ","))
+	}
+
+	if o.Kind == data.OperationAffected && o.Base != "" {
+		args = append(args, "--base="+o.Base)
+	}
+
+	if o.Parallel > 0 {
+		args = append(args, "--parallel="+strconv.Itoa(o.Parallel))
+	}
+
+	return args
+}
+
+type WorkspaceBenchmark data.WorkspaceBenchmark
+
+func (b *WorkspaceBenchmark) WriteStats() error {
+	b.CreatedAt = time.Now()
+
+	return data.Prepend(constants.WorkspaceAnalyserFilePath, b)
+}
+
+// Run starts the benchmark of the operation and returns its event stream.
+// The workers of the settings become the --parallel option of nx, the operation being a single unit.
+func Run(ctx context.Context, operation Operation, settings runner.Settings) <-chan runner.Event {
+	if settings.Workers > 1 {
+		operation.Parallel = settings.Workers
+	}
+	settings.Workers = 1
+
+	return runner.Start(ctx, runner.Options[WorkspaceBenchmark]{
+		Settings: settings,
+		Projects: []workspace.Project{operation},
+		Command: func(workspace.Project) []string {
+			return operation.args()
+		},
+		Tasks: readTasks,
+		Finish: func(result runner.Result) (WorkspaceBenchmark, error) {
+			benchmark := newWorkspaceBenchmark(operation, result, settings)
+
+			if err := benchmark.WriteStats(); err != nil {
+				return benchmark, fmt.Errorf("failed to write stats: %v", err)
+			}
+
+			return benchmark, nil
+		},
+	})
+}
+
+func newWorkspaceBenchmark(operation Operation, result runner.Result, settings runner.Settings) WorkspaceBenchmark {
+	tasks := summariseTasks(result.Runs)
+
+	return WorkspaceBenchmark{
+		ID:            result.ID,
+		Operation:     operation.Kind,
+		Targets:       operation.Targets,
+		Projects:      operation.Projects,
+		Base:          operation.Base,
+		Description:   settings.Description,
+		Duration:      time.Since(result.StartTime).Seconds(),
+		Summary:       stats.Summarise(result.Durations()),
+		Cache:         settings.Cache,
+		TargetOptions: settings.TargetOptions,
+		SuiteRun:      settings.Suite,
+		Parallel:      operation.Parallel,
+		TotalRuns:     result.TotalRuns(),
+		FailedRuns:    result.FailedRuns(),
+		SuccessRate:   result.SuccessRate(),
+		WarmupRuns:    settings.WarmupRuns,
+		OutlierPolicy: settings.Outliers,
+		Outliers:      result.Outliers(),
+		Cancelled:     result.Cancelled,
+		Tasks:         tasks,
+		UntimedTasks:  len(tasks) > 0 && !slices.ContainsFunc(tasks, func(task data.TaskSummary) bool { return task.Average > 0 }),
+		Runs:          result.Records(),
+	}
+}
+
+// summariseTasks aggregates the tasks of the runs included in the stats, the slowest tasks first.
+func summariseTasks(runs []runner.Run) []data.TaskSummary {
+	var (
+		summaries []data.TaskSummary
+		durations = make(map[string][]float64)
+	)
+
+	for _, run := range runs {
+		if run.Status != data.RunSucceeded || run.Excluded != "" {
+			continue
+		}
+
+		for _, task := range run.Tasks {
+			i := slices.IndexFunc(summaries, func(s data.TaskSummary) bool { return s.Task == task.Task })
+			if i == -1 {
+				summaries = append(summaries, data.TaskSummary{Task: task.Task, Project: task.Project, Target: task.Target})
+				i = len(summaries) - 1
+			}
+
+			summaries[i].Runs++
+			if task.Cached() {
+				summaries[i].CacheHits++
+			}
+
+			// the tasks only found in the output of nx are not timed
+			if task.Duration > 0 {
+				durations[task.Task] = append(durations[task.Task], task.Duration)
+			}
+		}
+	}
+
+	for i := range summaries {
+		summaries[i].Summary = stats.Summarise(durations[summaries[i].Task])
+	}
+
+	slices.SortStableFunc(summaries, func(a, b data.TaskSummary) int {
+		return utils.Ternary(a.Average > b.Average, -1, utils.Ternary(a.Average < b.Average, 1, 0))
+	})
+
+	return summaries
+}
@@ -0,0 +1,127 @@
+package workspace_analyser
+
+import (
+	"bufio"
+	"encoding/json"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// runSummaryPaths are where nx stores the summary of its last run, the cache folder depending on its version.
+var runSummaryPaths = []string{".nx/cache/run.json", "node_modules/.cache/nx/run.json"}
+
+// runSummary is the part of the run summary of nx holding the timings of the tasks.
+type runSummary struct {
+	Tasks []struct {
+		TaskID      string    `json:"taskId"`
+		Target      string    `json:"target"`
+		ProjectName string    `json:"projectName"`
+		StartTime   time.Time `json:"startTime"`
+		EndTime     time.Time `json:"endTime"`
+		CacheStatus string    `json:"cacheStatus"`
+		Status      int       `json:"status"`
+	} `json:"tasks"`
+}
+
+var (
+	// taskHeader matches the line printed by nx before the output of a task, e.g. "> nx run shell:build:production [local cache]".
+	taskHeader = regexp.MustCompile(`^\s*>\s+nx run ([^:\s]+):([^:\s]+)\S*(.*)$`)
+	ansiCodes  = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// readTasks returns the tasks of the run started at startTime. They are read from the run summary of nx
+// when it was written by the run, from the task headers of the log otherwise, without their durations.
+func readTasks(log string, startTime time.Time) []data.TaskRun {
+	if tasks := readRunSummary(startTime); len(tasks) > 0 {
+		return tasks
+	}
+
+	return parseTaskHeaders(log)
+}
+
+func readRunSummary(startTime time.Time) []data.TaskRun {
+	for _, path := range runSummaryPaths {
+		info, err := os.Stat(path)
+		// a summary older than the run was left by another nx command
+		if err != nil || info.ModTime().Before(startTime) {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var summary runSummary
+		if err := json.Unmarshal(content, &summary); err != nil {
+			continue
+		}
+
+		tasks := make([]data.TaskRun, 0, len(summary.Tasks))
+		for _, task := range summary.Tasks {
+			tasks = append(tasks, data.TaskRun{
+				Task:        task.TaskID,
+				Project:     task.ProjectName,
+				Target:      task.Target,
+				Duration:    task.EndTime.Sub(task.StartTime).Seconds(),
+				CacheStatus: task.CacheStatus,
+				Status:      task.Status,
+			})
+		}
+
+		return tasks
+	}
+
+	return nil
+}
+
+func parseTaskHeaders(log string) []data.TaskRun {
+	file, err := os.Open(log)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var tasks []data.TaskRun
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		match := taskHeader.FindStringSubmatch(ansiCodes.ReplaceAllString(scanner.Text(), ""))
+		if match == nil {
+			continue
+		}
+
+		task := data.TaskRun{
+			Task:        match[1] + ":" + match[2],
+			Project:     match[1],
+			Target:      match[2],
+			CacheStatus: cacheStatus(match[3]),
+		}
+
+		if !slices.ContainsFunc(tasks, func(t data.TaskRun) bool { return t.Task == task.Task }) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks
+}
+
+// cacheStatus translates the note nx prints after the task header into the cache status of its run summary.
+func cacheStatus(note string) string {
+	switch {
+	case strings.Contains(note, "local cache"):
+		return "local-cache-hit"
+	case strings.Contains(note, "remote cache"):
+		return "remote-cache-hit"
+	case strings.Contains(note, "existing outputs match the cache"):
+		return "local-cache-kept-existing"
+	}
+
+	return "cache-miss"
+}
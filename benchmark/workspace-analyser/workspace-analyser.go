@@ -0,0 +1,514 @@
+package workspace_analyser
+
+import (
+	"context"
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	form "github.com/ionut-t/gonx/benchmark/shared-form"
+	"github.com/ionut-t/gonx/internal/keymap"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/logs"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/ui/suspense"
+	"github.com/ionut-t/gonx/ui/tail"
+	"github.com/ionut-t/gonx/ui/viewport"
+	"github.com/ionut-t/gonx/workspace"
+	"strings"
+	"time"
+)
+
+const resultTitle = "📊 Benchmark results"
+
+const padding = 2
+
+var buildHint = fmt.Sprintf(
+	"Press %s to cancel the benchmark or %s to toggle the output.",
+	keymap.Cancel.Help().Key,
+	keymap.Output.Help().Key,
+)
+
+var logsHint = fmt.Sprintf("Press %s to browse the logs of the runs.", keymap.Logs.Help().Key)
+
+type view int
+
+const (
+	operationView view = iota
+	formView
+	buildView
+	resultsView
+	logsView
+)
+
+type Model struct {
+	view          view
+	operationForm operationForm
+	operation     Operation
+	form          form.Model
+	logs          logs.Model
+	viewport      viewport.Model
+	suspense      suspense.Model
+	progress      progress.Model
+	output        tail.Model
+
+	width  int
+	height int
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	cancelled  bool
+	events     <-chan runner.Event
+	completed  int
+	totalSteps int
+	runLogs    []logs.Entry
+	results    []WorkspaceBenchmark
+}
+
+// New creates the benchmark of a workspace whose projects and targets can be picked in the form of the operation.
+// The selected projects are preselected for run-many.
+func New(ctx context.Context, targets []string, projects []workspace.Project, selected []string, width, height int) Model {
+	names := make([]string, len(projects))
+	for i, project := range projects {
+		names[i] = project.GetName()
+	}
+
+	return Model{
+		ctx:           ctx,
+		width:         width,
+		height:        height,
+		operationForm: newOperationForm(targets, names, selected),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.operationForm.Init()
+}
+
+func (m Model) View() string {
+	switch m.view {
+	case operationView:
+		return lipgloss.NewStyle().Padding(1, 1).Render(m.operationForm.View())
+
+	case formView:
+		return lipgloss.NewStyle().Padding(1, 1).Render(m.form.View())
+
+	case buildView:
+		return m.output.Under(m.statusView())
+
+	case resultsView:
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.Header("", resultTitle),
+			m.viewport.View(),
+			lipgloss.NewStyle().Padding(0, 1).Render(styles.DimText.Render(logsHint)),
+		)
+
+	case logsView:
+		return m.logs.View()
+	}
+
+	return ""
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	if m.view == logsView {
+		if _, ok := msg.(logs.CloseMsg); ok {
+			m.view = resultsView
+			return m, nil
+		}
+
+		m.logs, cmd = m.logs.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.progress.Width = m.width - padding*2
+		m.output.SetSize(m.statusView(), m.width, m.height)
+
+	case form.FormMsg:
+		m.view = buildView
+
+		return m, messages.Dispatch(StartMsg{
+			StartTime: time.Now(),
+			Operation: m.operation,
+			Settings: runner.Settings{
+				Description: msg.Description,
+				Runs:        msg.Count,
+				KeepPartial: msg.KeepPartial,
+				WarmupRuns:  msg.WarmupRuns,
+				Outliers:    msg.Outliers,
+				Cache:       msg.Cache,
+				TargetOptions: data.TargetOptions{
+					Args: msg.Args,
+					Env:  msg.Env,
+				},
+				Workers: msg.Workers,
+			},
+		})
+
+	case StartMsg:
+		m.completed = 0
+		// every warm-up and measured run, plus writing the stats
+		m.totalSteps = msg.Settings.WarmupRuns + msg.Settings.Runs + 1
+		m.results = make([]WorkspaceBenchmark, 0)
+		m.suspense = suspense.New(fmt.Sprintf("Starting the benchmark of nx %s", msg.Operation.GetName()), true)
+		m.progress = progress.New(progress.WithDefaultGradient())
+		m.progress.Width = m.width - padding*2
+		m.progress.PercentageStyle = styles.Primary
+		m.output = tail.New(m.statusView(), m.width, m.height)
+		m.cancelled = false
+		m.runLogs = nil
+
+		var ctx context.Context
+		ctx, m.cancel = context.WithCancel(m.ctx)
+		m.events = Run(ctx, msg.Operation, msg.Settings)
+
+		return m, tea.Batch(
+			m.listen(),
+			m.suspense.Spinner.Tick,
+			m.progress.SetPercent(0.0),
+		)
+
+	case runner.ResetStarted:
+		m.suspense.Message = "Resetting the Nx cache and stopping the daemon"
+		return m, m.listen()
+
+	case runner.ResetFinished:
+		if msg.Error != nil {
+			m.suspense.Message = msg.Error.Error()
+		}
+		return m, m.listen()
+
+	case runner.RunStarted:
+		m.suspense.Message = fmt.Sprintf("Running %s (%s)",
+			styles.Primary.Bold(true).Render("nx "+msg.Project.GetName()),
+			msg.Label(),
+		)
+		m.output.Reset(fmt.Sprintf("nx %s (%s)", msg.Project.GetName(), msg.Label()))
+		return m, m.listen()
+
+	case runner.Output:
+		m.output.Push(msg.Project.GetName(), msg.Lines...)
+		return m, m.listen()
+
+	case runner.RunFinished:
+		m.completed++
+		if msg.Error != nil {
+			m.suspense.Message = msg.Error.Error()
+		}
+		if msg.Log != "" {
+			m.runLogs = append(m.runLogs, logs.Entry{
+				Title:  fmt.Sprintf("nx %s (%s)", msg.Project.GetName(), msg.Label()),
+				Path:   msg.Log,
+				Failed: msg.Status == data.RunFailed,
+			})
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
+
+	case runner.StatsStarted:
+		m.suspense.Message = "Writing stats..."
+		return m, m.listen()
+
+	case runner.StatsWritten[WorkspaceBenchmark]:
+		m.completed++
+		if msg.Error != nil {
+			m.suspense.Message = msg.Error.Error()
+		} else {
+			m.results = append(m.results, msg.Benchmark)
+		}
+		return m, tea.Batch(m.progress.SetPercent(m.progressPercent()), m.listen())
+
+	case CompleteMsg:
+		m.cancel()
+		return m, tea.Sequence(
+			m.progress.SetPercent(1.0),
+			// wait for the progress bar to finish animating
+			tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
+				return DoneMsg{}
+			}),
+		)
+
+	case DoneMsg:
+		renderBenchmarkResults(&m)
+		m.viewport.Update(msg)
+		m.suspense.Loading = false
+		m.view = resultsView
+
+	case spinner.TickMsg:
+		if m.suspense.Loading {
+			var suspenseModel tea.Model
+			suspenseModel, cmd = m.suspense.Update(msg)
+			m.suspense = suspenseModel.(suspense.Model)
+			return m, cmd
+		}
+
+	// FrameMsg is sent when the progress bar wants to animate itself
+	case progress.FrameMsg:
+		progressModel, cmd := m.progress.Update(msg)
+		m.progress = progressModel.(progress.Model)
+		return m, cmd
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keymap.Cancel):
+			if m.view == buildView && m.cancel != nil && !m.cancelled {
+				m.cancel()
+				m.cancelled = true
+				m.suspense.Message = "Cancelling the benchmark..."
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Output):
+			if m.view == buildView {
+				m.output.Toggle()
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Logs):
+			if m.view == resultsView {
+				m.logs = logs.New(m.runLogs, m.width, m.height)
+				m.view = logsView
+				return m, nil
+			}
+
+		case key.Matches(msg, keymap.Back):
+			// the settings go back to the operation, which has no project list to go back to
+			if m.view == formView {
+				m.view = operationView
+				m.operationForm = newOperationForm(m.operationForm.targets, m.operationForm.projects, m.operationForm.selected)
+				return m, m.operationForm.Init()
+			}
+
+			if m.view != buildView {
+				return m, messages.Dispatch(messages.NavigateToViewMsg(0))
+			}
+		}
+	}
+
+	// Handle keyboard and mouse events in the viewport
+	viewportModel, cmd := m.viewport.Update(msg)
+	m.viewport = viewportModel.(viewport.Model)
+	cmds = append(cmds, cmd)
+
+	switch m.view {
+	case operationView:
+		m.operationForm, cmd = m.operationForm.Update(msg)
+		cmds = append(cmds, cmd)
+
+		if m.operationForm.completed() {
+			m.operation = m.operationForm.operation()
+			m.form = form.New()
+			m.view = formView
+			cmds = append(cmds, m.form.Init())
+		}
+
+	case formView:
+		formModel, cmd := m.form.Update(msg)
+		m.form = formModel.(form.Model)
+		cmds = append(cmds, cmd)
+
+	case buildView:
+		suspenseModel, cmd := m.suspense.Update(msg)
+		m.suspense = suspenseModel.(suspense.Model)
+		cmds = append(cmds, cmd)
+
+		progressModel, cmd := m.progress.Update(msg)
+		m.progress = progressModel.(progress.Model)
+		cmds = append(cmds, cmd)
+
+	default:
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) statusView() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Padding(1, 1).Render(m.suspense.View()),
+		lipgloss.NewStyle().Padding(0, 1).Render(m.progress.View()),
+		lipgloss.NewStyle().Padding(1, 1).Render(styles.DimText.Render(buildHint)),
+	)
+}
+
+func (m Model) listen() tea.Cmd {
+	return messages.Listen(m.events, CompleteMsg{})
+}
+
+func (m Model) progressPercent() float64 {
+	return float64(m.completed) / float64(m.totalSteps)
+}
+
+func renderBenchmarkResults(m *Model) {
+	results := m.results
+
+	var contents []string
+
+	border := styles.NormalText.Render(strings.Repeat("─", min(50, m.width-padding)))
+
+	for i, bm := range results {
+		content := lipgloss.JoinVertical(
+			lipgloss.Left,
+			border,
+			fmt.Sprintf("Stats for %s:", styles.Primary.Bold(true).Render("nx "+data.WorkspaceBenchmark(bm).Command())),
+			border,
+			renderStats(bm),
+			border,
+			renderTasks(bm),
+		)
+
+		if i < len(results)-1 {
+			content += "\n"
+		}
+
+		contents = append(contents, content)
+	}
+
+	if m.cancelled {
+		contents = append([]string{styles.Warning.Render(cancelledMessage(len(results))) + "\n"}, contents...)
+	}
+
+	output := lipgloss.NewStyle().
+		Padding(0, 4).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			contents...,
+		))
+
+	options := viewport.Options{
+		Width:   m.width,
+		Height:  m.viewportHeight(),
+		Content: output,
+	}
+
+	m.viewport = viewport.New(options)
+}
+
+func renderStats(bm WorkspaceBenchmark) string {
+	if data.AllRunsFailed(bm.TotalRuns, bm.FailedRuns) {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.Error.Render(fmt.Sprintf("%sAll %d runs failed, no stats were recorded", styles.IconStyle("❌"), bm.TotalRuns)),
+			styles.Info.Render(fmt.Sprintf("%sCache: %s", styles.IconStyle("💾"), bm.Cache)),
+		)
+	}
+
+	stats := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.Success.Render(fmt.Sprintf("%sMin: %.2fs", styles.IconStyle("🕒"), bm.Min)),
+		styles.Success.Render(fmt.Sprintf("%sMax: %.2fs", styles.IconStyle("🕒"), bm.Max)),
+		styles.Success.Render(fmt.Sprintf("%sAverage: %.2fs", styles.IconStyle("🕒"), bm.Average)),
+		styles.Success.Render(fmt.Sprintf("%sMedian: %.2fs", styles.IconStyle("🕒"), bm.Median)),
+		styles.Success.Render(fmt.Sprintf("%sp90: %.2fs, p95: %.2fs", styles.IconStyle("📈"), bm.P90, bm.P95)),
+		styles.Info.Render(fmt.Sprintf("%sStd dev: %.2fs (CV %.1f%%)", styles.IconStyle("📉"), bm.StdDev, bm.CV*100)),
+		styles.Info.Render(fmt.Sprintf("%s95%% CI: %.2fs – %.2fs", styles.IconStyle("🎯"), bm.CILow, bm.CIHigh)),
+		styles.Success.Render(fmt.Sprintf("%sTotal runs: %d", styles.IconStyle("🔄"), bm.TotalRuns)),
+		styles.Info.Render(fmt.Sprintf("%sCache: %s", styles.IconStyle("💾"), bm.Cache)),
+	)
+
+	if options := bm.TargetOptions.String(); options != "" {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sOptions: %s", styles.IconStyle("🔩"), options)),
+		)
+	}
+
+	if bm.Parallel > 0 {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sParallel: up to %d tasks at a time", styles.IconStyle("👷"), bm.Parallel)),
+		)
+	}
+
+	if bm.WarmupRuns > 0 {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sWarm-up runs: %d, excluded from the stats", styles.IconStyle("🔥"), bm.WarmupRuns)),
+		)
+	}
+
+	if bm.OutlierPolicy != "" && bm.OutlierPolicy != data.OutliersNone {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Info.Render(fmt.Sprintf("%sOutliers rejected (%s): %d", styles.IconStyle("🧹"), strings.ToUpper(string(bm.OutlierPolicy)), bm.Outliers)),
+		)
+	}
+
+	if bm.FailedRuns > 0 {
+		stats = lipgloss.JoinVertical(
+			lipgloss.Left,
+			stats,
+			styles.Error.Render(fmt.Sprintf(
+				"%sFailed runs: %d, the stats only include the successful runs (success rate %.0f%%)",
+				styles.IconStyle("❌"),
+				bm.FailedRuns,
+				bm.SuccessRate*100,
+			)),
+		)
+	}
+
+	return stats
+}
+
+// renderTasks lists the tasks run by the benchmarked command, the slowest first.
+func renderTasks(bm WorkspaceBenchmark) string {
+	if len(bm.Tasks) == 0 {
+		return styles.DimText.Render("No task was found in the run summary or in the output of nx.")
+	}
+
+	width := 0
+	for _, task := range bm.Tasks {
+		width = max(width, len(task.Task))
+	}
+
+	lines := []string{fmt.Sprintf("%sTasks (%d):", styles.IconStyle("🧩"), len(bm.Tasks))}
+
+	if bm.UntimedTasks {
+		lines = append(lines, styles.DimText.Render("  Per-task timings are unavailable, nx wrote no run summary: the tasks were found in its output."))
+	}
+
+	for _, task := range bm.Tasks {
+		duration := "not timed"
+		if task.Runs > 0 && task.Average > 0 {
+			duration = fmt.Sprintf("avg %.2fs, min %.2fs, max %.2fs", task.Average, task.Min, task.Max)
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"  %s  %s  %s",
+			styles.Primary.Render(fmt.Sprintf("%-*s", width, task.Task)),
+			duration,
+			styles.DimText.Render(fmt.Sprintf("cache hits %d/%d", task.CacheHits, task.Runs)),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func cancelledMessage(results int) string {
+	if results == 0 {
+		return "The benchmark was cancelled, no results were recorded."
+	}
+
+	return "The benchmark was cancelled, the results below are partial."
+}
+
+func (m Model) viewportHeight() int {
+	return m.height - lipgloss.Height(styles.Header(resultTitle)) - lipgloss.Height(logsHint)
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	testsAnalyser "github.com/ionut-t/gonx/benchmark/tests-analyser"
+	"github.com/ionut-t/gonx/workspace"
+)
+
+func runBuild(args []string) int {
+	opts, err := parseFlags("build", "apps", true, args)
+	if err != nil {
+		return flagsExitCode(err)
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		return printError(err)
+	}
+
+	projects, err := selectProjects(ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType}), opts)
+	if err != nil {
+		return printError(err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	events := testsAnalyser.Run(ctx, testsAnalyser.Build, projects, opts.settings())
+
+	return reportTests(events, "Building")
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	bundleAnalyser "github.com/ionut-t/gonx/benchmark/bundle-analyser"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"os"
+)
+
+func runBundle(args []string) int {
+	opts, err := parseFlags("bundle", "apps", false, args)
+	if err != nil {
+		return flagsExitCode(err)
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		return printError(err)
+	}
+
+	projects, err := selectProjects(ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType}), opts)
+	if err != nil {
+		return printError(err)
+	}
+
+	apps := make([]workspace.Application, 0, len(projects))
+	for _, project := range projects {
+		apps = append(apps, project.(workspace.Application))
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	events := bundleAnalyser.Run(ctx, apps, opts.description, opts.targetOptions(), data.SuiteRun{})
+
+	return reportBundle(events)
+}
+
+// reportBundle prints the events of the bundle analyser, and fails when an app exceeds an error budget
+// or a performance budget although it was built.
+func reportBundle(events <-chan runner.Event) int {
+	overBudget := 0
+
+	code := reportBudgets(
+		events,
+		"Building",
+		func(bm bundleAnalyser.BundleBenchmark) string {
+			if data.BundleBenchmark(bm).BudgetStatus() == data.BudgetError {
+				overBudget++
+			}
+
+			return bundleSummary(bm)
+		},
+		func(bm bundleAnalyser.BundleBenchmark) data.BudgetChecks {
+			return bm.BudgetChecks
+		},
+	)
+
+	if overBudget > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "%d app(s) exceeded an error budget.\n", overBudget)
+		return exitFailure
+	}
+
+	return code
+}
+
+func bundleSummary(bm bundleAnalyser.BundleBenchmark) string {
+	summary := fmt.Sprintf(
+		"built in %.2fs, initial %s, lazy %s, overall %s",
+		bm.Duration,
+		utils.FormatFileSize(bm.Stats.Initial.Total),
+		utils.FormatFileSize(bm.Stats.Lazy),
+		utils.FormatFileSize(bm.Stats.OverallTotal),
+	)
+
+	if len(bm.Budgets) > 0 {
+		summary += fmt.Sprintf(", budgets %s", data.BundleBenchmark(bm).BudgetStatus())
+	}
+
+	// the budgets exceeded, each on its own line
+	for _, budget := range bm.Budgets {
+		if budget.Status == data.BudgetWarning || budget.Status == data.BudgetError {
+			summary += fmt.Sprintf("\n  %s budget %s", budget.Status, budget)
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/utils"
+	"github.com/ionut-t/gonx/workspace"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(args []string) int
+}
+
+var commands = []command{
+	{
+		name:        "bundle",
+		usage:       "gonx bundle [--apps a,b | --preset name] [--configuration name] [--args \"...\"] [--env \"KEY=VALUE ...\"] [--description text]",
+		description: "Build the applications once and record their bundle sizes",
+		run:         runBundle,
+	},
+	{
+		name:        "build",
+		usage:       "gonx build [--apps a,b | --preset name] [--runs n] [--warmup n] [--outliers none|iqr|mad] [--cache cold|reset-once|warm|skip-nx-cache] [--daemon] [--workers n] [--configuration name] [--args \"...\"] [--env \"KEY=VALUE ...\"] [--description text] [--keep-partial]",
+		description: "Time the build of the applications over n runs",
+		run:         runBuild,
+	},
+	{
+		name:        "lint",
+		usage:       "gonx lint [--projects a,b | --preset name] [--runs n] [--warmup n] [--outliers none|iqr|mad] [--cache cold|reset-once|warm|skip-nx-cache] [--daemon] [--workers n] [--configuration name] [--args \"...\"] [--env \"KEY=VALUE ...\"] [--description text] [--keep-partial]",
+		description: "Time the lint target of the projects over n runs",
+		run:         runLint,
+	},
+	{
+		name:        "test",
+		usage:       "gonx test [--projects a,b | --preset name] [--runs n] [--warmup n] [--outliers none|iqr|mad] [--cache cold|reset-once|warm|skip-nx-cache] [--daemon] [--workers n] [--configuration name] [--args \"...\"] [--env \"KEY=VALUE ...\"] [--description text] [--keep-partial]",
+		description: "Time the test target of the projects over n runs",
+		run:         runTests,
+	},
+	{
+		name:        "target",
+		usage:       "gonx target <target> [--projects a,b | --preset name] [--runs n] [--warmup n] [--outliers none|iqr|mad] [--cache cold|reset-once|warm|skip-nx-cache] [--daemon] [--workers n] [--configuration name] [--args \"...\"] [--env \"KEY=VALUE ...\"] [--description text] [--keep-partial]",
+		description: "Time any target of the projects defining it over n runs",
+		run:         runTarget,
+	},
+	{
+		name:        "workspace",
+		usage:       "gonx workspace run-many|affected --targets a,b [--projects a,b | --preset name] [--base ref] [--parallel n] [--runs n] [--warmup n] [--outliers none|iqr|mad] [--cache cold|reset-once|warm|skip-nx-cache] [--daemon] [--configuration name] [--args \"...\"] [--env \"KEY=VALUE ...\"] [--description text] [--keep-partial]",
+		description: "Time nx run-many or nx affected as a whole over n runs",
+		run:         runWorkspace,
+	},
+	{
+		name:        "suite",
+		usage:       "gonx suite <name> [--keep-partial]",
+		description: "Run every step of a suite defined in .gonx/config.json",
+		run:         runSuite,
+	},
+}
+
+// Run executes the headless command described by args and returns the exit code of the process.
+// Any failed run makes the command exit with a non-zero code.
+func Run(args []string) int {
+	name := args[0]
+
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage(os.Stdout)
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(args[1:])
+		}
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Unknown command %q.\n\n", name)
+	printUsage(os.Stderr)
+
+	return exitUsage
+}
+
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Usage: gonx [command] [flags]")
+	_, _ = fmt.Fprintln(w, "\nRun gonx without a command to start the interactive mode.")
+	_, _ = fmt.Fprintln(w, "\nCommands:")
+
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", "", cmd.usage)
+	}
+}
+
+type options struct {
+	projects      string
+	preset        string
+	runs          int
+	warmupRuns    int
+	outliers      string
+	cache         string
+	daemon        bool
+	workers       int
+	configuration string
+	args          string
+	env           []string
+	description   string
+	keepPartial   bool
+}
+
+func (o options) settings() runner.Settings {
+	return runner.Settings{
+		Description:   o.description,
+		Runs:          o.runs,
+		KeepPartial:   o.keepPartial,
+		WarmupRuns:    o.warmupRuns,
+		Outliers:      data.OutlierPolicy(o.outliers),
+		Cache:         data.Cache{CacheMode: data.CacheMode(o.cache), Daemon: o.daemon},
+		TargetOptions: o.targetOptions(),
+		Workers:       o.workers,
+	}
+}
+
+func (o options) targetOptions() data.TargetOptions {
+	return data.TargetOptions{
+		Configuration: o.configuration,
+		Args:          strings.Fields(o.args),
+		Env:           o.env,
+	}
+}
+
+// parseFlags parses the flags shared by the commands, extra registering the flags specific to a command.
+func parseFlags(name, projectsFlag string, withRuns bool, args []string, extra ...func(flags *flag.FlagSet)) (options, error) {
+	var opts options
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&opts.projects, projectsFlag, "", "comma separated list of "+projectsFlag+" (defaults to all)")
+	flags.StringVar(&opts.preset, "preset", "", "name of a preset of projects saved from the project selection")
+	flags.StringVar(&opts.description, "description", "", "optional description for the benchmark")
+	flags.StringVar(&opts.configuration, "configuration", "", "configuration of the target (defaults to the default configuration)")
+	flags.StringVar(&opts.args, "args", "", "space separated extra arguments for the target, e.g. \"--coverage=false --ci\"")
+	env := flags.String("env", "", "space separated extra environment variables, e.g. \"CI=true NODE_ENV=production\"")
+
+	if withRuns {
+		flags.IntVar(&opts.runs, "runs", 1, "how many times each target should run (1-100)")
+		flags.IntVar(&opts.warmupRuns, "warmup", 0, "how many warm-up runs, excluded from the stats, should run first (0-10)")
+		flags.StringVar(&opts.outliers, "outliers", string(data.OutliersNone), "how outliers are rejected before aggregating: none, iqr or mad")
+		flags.StringVar(&opts.cache, "cache", string(data.CacheCold), "how the Nx cache is handled: cold, reset-once, warm or skip-nx-cache")
+		flags.BoolVar(&opts.daemon, "daemon", false, "run the targets with the Nx daemon")
+		flags.IntVar(&opts.workers, "workers", 1, "how many projects are benchmarked in parallel (1-32)")
+		flags.BoolVar(&opts.keepPartial, "keep-partial", false, "keep the completed runs when the benchmark is interrupted")
+	}
+
+	for _, register := range extra {
+		register(flags)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if opts.projects != "" && opts.preset != "" {
+		return opts, fmt.Errorf("%s and preset cannot be used together", projectsFlag)
+	}
+
+	var err error
+	if opts.env, err = data.ParseEnv(*env); err != nil {
+		return opts, fmt.Errorf("env: %v", err)
+	}
+
+	if withRuns && (opts.runs <= 0 || opts.runs > 100) {
+		return opts, fmt.Errorf("runs must be between 1 and 100")
+	}
+
+	if withRuns && (opts.workers < 1 || opts.workers > 32) {
+		return opts, fmt.Errorf("workers must be between 1 and 32")
+	}
+
+	if withRuns && (opts.warmupRuns < 0 || opts.warmupRuns > 10) {
+		return opts, fmt.Errorf("warmup must be between 0 and 10")
+	}
+
+	if withRuns {
+		switch data.OutlierPolicy(opts.outliers) {
+		case data.OutliersNone, data.OutliersIQR, data.OutliersMAD:
+		default:
+			return opts, fmt.Errorf("outliers must be one of none, iqr or mad")
+		}
+
+		if !slices.Contains(data.CacheModes, data.CacheMode(opts.cache)) {
+			return opts, fmt.Errorf("cache must be one of cold, reset-once, warm or skip-nx-cache")
+		}
+	}
+
+	return opts, nil
+}
+
+// interruptContext returns a context cancelled on SIGINT or SIGTERM, so that
+// interrupting gonx also stops the running nx process.
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func loadWorkspace() (*workspace.Model, error) {
+	fmt.Println("Loading workspace...")
+
+	ws, err := workspace.Load()
+	if err != nil {
+		return nil, fmt.Errorf("no Nx workspace found. Please run this command in the root of your Nx workspace")
+	}
+
+	for _, skipped := range ws.Skipped {
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: %s\n", skipped)
+	}
+
+	return ws, nil
+}
+
+// selectProjects returns the projects matching the comma separated names or the projects of the preset,
+// or all the available projects when neither is provided.
+func selectProjects(available []workspace.Project, opts options) ([]workspace.Project, error) {
+	if opts.preset != "" {
+		return selectPreset(available, opts.preset)
+	}
+
+	names := opts.projects
+
+	if strings.TrimSpace(names) == "" {
+		if len(available) == 0 {
+			return nil, fmt.Errorf("no projects found in the workspace")
+		}
+
+		return available, nil
+	}
+
+	var selected []workspace.Project
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		idx := slices.IndexFunc(available, func(p workspace.Project) bool {
+			return p.GetName() == name
+		})
+
+		if idx == -1 {
+			return nil, fmt.Errorf("project %s not found", name)
+		}
+
+		selected = append(selected, available[idx])
+	}
+
+	return selected, nil
+}
+
+// selectPreset returns the available projects saved in the preset, leaving out the ones the command cannot run.
+func selectPreset(available []workspace.Project, name string) ([]workspace.Project, error) {
+	preset, err := workspace.FindPreset(name)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := utils.Filter(available, func(project workspace.Project) bool {
+		return slices.Contains(preset.Projects, project.GetName())
+	})
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no project of preset %s can run this command", name)
+	}
+
+	return selected, nil
+}
+
+func printError(err error) int {
+	_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return exitFailure
+}
+
+func exitCode(failed int) int {
+	if failed > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "%d run(s) failed.\n", failed)
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+func flagsExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return exitUsage
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	testsAnalyser "github.com/ionut-t/gonx/benchmark/tests-analyser"
+	"github.com/ionut-t/gonx/workspace"
+)
+
+func runLint(args []string) int {
+	opts, err := parseFlags("lint", "projects", true, args)
+	if err != nil {
+		return flagsExitCode(err)
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		return printError(err)
+	}
+
+	projects, err := selectProjects(ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType}), opts)
+	if err != nil {
+		return printError(err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	events := testsAnalyser.Run(ctx, testsAnalyser.Lint, projects, opts.settings())
+
+	return reportTests(events, "Linting")
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/internal/constants"
+	"github.com/ionut-t/gonx/utils"
+	"os"
+	"strings"
+)
+
+// report prints the events of a benchmark as plain lines until the stream is closed
+// and returns the exit code of the command.
+func report[T any](events <-chan runner.Event, action string, summary func(T) string) int {
+	failed := 0
+
+	for event := range events {
+		switch event := event.(type) {
+		case runner.ResetStarted:
+			fmt.Println("Resetting the Nx cache")
+
+		case runner.ResetFinished:
+			if event.Error != nil {
+				failed++
+				fmt.Printf("Failed: %v\n", event.Error)
+			}
+
+		case runner.RunStarted:
+			fmt.Printf("%s %s (%s)\n", action, event.Project.GetName(), event.Label())
+
+		case runner.RunFinished:
+			if event.Error != nil {
+				failed++
+				fmt.Printf("Failed %s (%s): %v\n", event.Project.GetName(), event.Label(), event.Error)
+				if event.Log != "" && event.Status == data.RunFailed {
+					fmt.Printf("See the output in %s\n", event.Log)
+				}
+			} else {
+				fmt.Printf("Finished %s (%s) in %.2fs\n", event.Project.GetName(), event.Label(), event.Duration)
+			}
+
+		case runner.StatsStarted:
+			fmt.Printf("Writing stats for %s\n", event.Project.GetName())
+
+		case runner.StatsWritten[T]:
+			if event.Error != nil {
+				failed++
+				fmt.Printf("Failed %s: %v\n", event.Project.GetName(), event.Error)
+			} else {
+				fmt.Printf("Done %s: %s\n", event.Project.GetName(), summary(event.Benchmark))
+			}
+		}
+	}
+
+	return exitCode(failed)
+}
+
+// durationSummary describes the durations of the runs included in the stats.
+func durationSummary(summary data.Summary, runs int) string {
+	if runs == 0 {
+		return "all runs failed, no stats were recorded"
+	}
+
+	return fmt.Sprintf(
+		"min %.2fs, max %.2fs, average %.2fs, median %.2fs, p95 %.2fs, CV %.1f%%, 95%% CI %.2fs-%.2fs over %d runs",
+		summary.Min,
+		summary.Max,
+		summary.Average,
+		summary.Median,
+		summary.P95,
+		summary.CV*100,
+		summary.CILow,
+		summary.CIHigh,
+		runs,
+	)
+}
+
+// reportBudgets prints the events like report, with the performance budgets checked against each benchmark,
+// and fails when a benchmark is over one of them although its runs succeeded.
+func reportBudgets[T any](events <-chan runner.Event, action string, summary func(T) string, checks func(T) data.BudgetChecks) int {
+	overBudget := 0
+
+	code := report(events, action, func(bm T) string {
+		budgets := checks(bm)
+		if !budgets.Passed() {
+			overBudget++
+		}
+
+		// the status goes on the first line, before the details of the analyser, e.g. the Angular budgets
+		headline, details, found := strings.Cut(summary(bm), "\n")
+
+		return headline + budgetsSummary(budgets) + utils.Ternary(found, "\n"+details, "")
+	})
+
+	if overBudget > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "%d project(s) exceeded a performance budget of %s.\n", overBudget, constants.ConfigFilePath)
+		return exitFailure
+	}
+
+	return code
+}
+
+// budgetsSummary describes the performance budgets of a benchmark, the ones exceeded on their own lines.
+func budgetsSummary(checks data.BudgetChecks) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	summary := ", performance budgets " + utils.Ternary(checks.Passed(), "passed", "failed")
+
+	for _, check := range checks {
+		if !check.Passed {
+			summary += fmt.Sprintf("\n  failed performance budget %s", check)
+		}
+	}
+
+	return summary
+}
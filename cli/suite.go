@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/benchmark/suite"
+	"github.com/ionut-t/gonx/internal/constants"
+	"github.com/ionut-t/gonx/utils"
+	"os"
+	"strings"
+)
+
+func runSuite(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: the suite to run is required, e.g. gonx suite release\n")
+		printSuites()
+		return exitUsage
+	}
+
+	var keepPartial bool
+
+	flags := flag.NewFlagSet("suite "+args[0], flag.ContinueOnError)
+	flags.BoolVar(&keepPartial, "keep-partial", false, "keep the completed runs when the suite is interrupted")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return flagsExitCode(err)
+	}
+
+	s, err := suite.Find(args[0])
+	if err != nil {
+		return printError(err)
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		return printError(err)
+	}
+
+	run, err := suite.New(s, ws)
+	if err != nil {
+		return printError(err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	fmt.Printf("Running suite %s (run %s)\n", s.Name, run.ID)
+
+	failedSteps := 0
+
+	for i, plan := range run.Steps {
+		if ctx.Err() != nil {
+			break
+		}
+
+		plan.Settings.KeepPartial = keepPartial
+
+		fmt.Printf("\nStep %d/%d: %s\n", i+1, len(run.Steps), plan.Title())
+
+		if reportPlan(plan, plan.Start(ctx)) != exitOK {
+			failedSteps++
+		}
+	}
+
+	if ctx.Err() != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "The suite was cancelled.")
+		return exitFailure
+	}
+
+	if failedSteps > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "%d of %d steps failed.\n", failedSteps, len(run.Steps))
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// reportPlan prints the events of a step the way the command of its analyser does.
+func reportPlan(plan suite.Plan, events <-chan runner.Event) int {
+	switch plan.Analyser {
+	case suite.BundleAnalyser:
+		return reportBundle(events)
+
+	case suite.BuildAnalyser:
+		return reportTests(events, "Building")
+
+	case suite.LintAnalyser:
+		return reportTests(events, "Linting")
+
+	case suite.TestAnalyser:
+		return reportTests(events, "Testing")
+
+	case suite.TargetAnalyser:
+		return reportTests(events, "Running "+plan.Target+" on")
+	}
+
+	return report(events, "Running nx", workspaceSummary)
+}
+
+// printSuites lists the suites of the configuration file, if any.
+func printSuites() {
+	suites, err := suite.Suites()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	if len(suites) == 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "No suite is defined in %s.\n", constants.ConfigFilePath)
+		return
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, "\nSuites:")
+	for _, s := range suites {
+		_, _ = fmt.Fprintf(os.Stderr, "  %-20s %d %s\n", s.Name, len(s.Steps), utils.Ternary(len(s.Steps) == 1, "step", "steps"))
+	}
+}
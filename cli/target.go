@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	testsAnalyser "github.com/ionut-t/gonx/benchmark/tests-analyser"
+	"os"
+	"strings"
+)
+
+func runTarget(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		_, _ = fmt.Fprintln(os.Stderr, "Error: the target to benchmark is required, e.g. gonx target e2e")
+		return exitUsage
+	}
+
+	target := args[0]
+
+	opts, err := parseFlags("target", "projects", true, args[1:])
+	if err != nil {
+		return flagsExitCode(err)
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		return printError(err)
+	}
+
+	projects, err := selectProjects(ws.GetProjectsWithTarget(target), opts)
+	if err != nil {
+		return printError(err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	events := testsAnalyser.Run(ctx, testsAnalyser.Target(target), projects, opts.settings())
+
+	return reportTests(events, "Running "+target+" on")
+}
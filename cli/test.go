@@ -0,0 +1,46 @@
+package cli
+
+import (
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	testsAnalyser "github.com/ionut-t/gonx/benchmark/tests-analyser"
+	"github.com/ionut-t/gonx/workspace"
+)
+
+func runTests(args []string) int {
+	opts, err := parseFlags("test", "projects", true, args)
+	if err != nil {
+		return flagsExitCode(err)
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		return printError(err)
+	}
+
+	projects, err := selectProjects(ws.GetProjects([]workspace.ProjectType{workspace.ApplicationType, workspace.LibraryType}), opts)
+	if err != nil {
+		return printError(err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	events := testsAnalyser.Run(ctx, testsAnalyser.Tests, projects, opts.settings())
+
+	return reportTests(events, "Testing")
+}
+
+// reportTests prints the events of the benchmark of the tests analyser, and fails when a project exceeds a performance budget.
+func reportTests(events <-chan runner.Event, action string) int {
+	return reportBudgets(
+		events,
+		action,
+		func(bm testsAnalyser.TestBenchmark) string {
+			return durationSummary(bm.Summary, bm.TotalRuns-bm.FailedRuns-bm.Outliers)
+		},
+		func(bm testsAnalyser.TestBenchmark) data.BudgetChecks {
+			return bm.BudgetChecks
+		},
+	)
+}
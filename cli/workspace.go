@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	workspaceAnalyser "github.com/ionut-t/gonx/benchmark/workspace-analyser"
+	"github.com/ionut-t/gonx/workspace"
+	"os"
+	"strings"
+)
+
+func runWorkspace(args []string) int {
+	if len(args) == 0 || (args[0] != string(data.OperationRunMany) && args[0] != string(data.OperationAffected)) {
+		_, _ = fmt.Fprintln(os.Stderr, "Error: the command to benchmark is required, either run-many or affected")
+		return exitUsage
+	}
+
+	operation := workspaceAnalyser.Operation{Kind: data.WorkspaceOperation(args[0])}
+
+	var targets string
+	opts, err := parseFlags("workspace "+args[0], "projects", true, args[1:], func(flags *flag.FlagSet) {
+		flags.StringVar(&targets, "targets", "", "comma separated list of targets to run, e.g. build,lint")
+		flags.StringVar(&operation.Base, "base", "", "base of nx affected (defaults to the default base of the workspace)")
+		flags.IntVar(&operation.Parallel, "parallel", 0, "how many tasks nx runs in parallel (defaults to the setting of the workspace)")
+	})
+	if err != nil {
+		return flagsExitCode(err)
+	}
+
+	operation.Targets = splitList(targets)
+	if len(operation.Targets) == 0 {
+		return flagsExitCode(fmt.Errorf("targets is required"))
+	}
+
+	if operation.Kind == data.OperationRunMany {
+		operation.Projects = splitList(opts.projects)
+	}
+
+	if operation.Kind == data.OperationRunMany && opts.preset != "" {
+		preset, err := workspace.FindPreset(opts.preset)
+		if err != nil {
+			return printError(err)
+		}
+
+		operation.Projects = preset.Projects
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	events := workspaceAnalyser.Run(ctx, operation, opts.settings())
+
+	return report(events, "Running nx", workspaceSummary)
+}
+
+func workspaceSummary(bm workspaceAnalyser.WorkspaceBenchmark) string {
+	summary := durationSummary(bm.Summary, bm.TotalRuns-bm.FailedRuns-bm.Outliers)
+
+	// the tasks are sorted by average duration, the slowest first
+	if bm.UntimedTasks {
+		summary += fmt.Sprintf(", %d tasks, per-task timings unavailable without a run summary of nx", len(bm.Tasks))
+	} else if len(bm.Tasks) > 0 {
+		summary += fmt.Sprintf(", %d tasks, the slowest %s (avg %.2fs)", len(bm.Tasks), bm.Tasks[0].Task, bm.Tasks[0].Average)
+	}
+
+	return summary
+}
+
+// splitList returns the non-empty names of the comma separated list.
+func splitList(list string) []string {
+	var names []string
+
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -4,6 +4,7 @@ go 1.22.4
 
 require (
 	github.com/alecthomas/chroma/v2 v2.15.0
+	github.com/andybalholm/brotli v1.2.6
 	github.com/catppuccin/go v0.2.0
 	github.com/charmbracelet/bubbles v0.20.0
 	github.com/charmbracelet/bubbletea v1.2.4
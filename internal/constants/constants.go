@@ -3,6 +3,10 @@ package constants
 const (
 	Folder                 = ".gonx"
 	BenchmarkFolderPath    = Folder + "/benchmarks"
+	LogsFolderPath         = Folder + "/logs"
+	WorkspaceCacheFilePath = Folder + "/workspace.json"
+	PresetsFilePath        = Folder + "/presets.json"
+	ConfigFilePath         = Folder + "/config.json"
 	BundleAnalyserFile     = "bundle-benchmarks.json"
 	BundleAnalyserFilePath = BenchmarkFolderPath + "/" + BundleAnalyserFile
 
@@ -14,4 +18,10 @@ const (
 
 	TestAnalyserFile     = "test-benchmarks.json"
 	TestAnalyserFilePath = BenchmarkFolderPath + "/" + TestAnalyserFile
+
+	TargetAnalyserFile     = "target-benchmarks.json"
+	TargetAnalyserFilePath = BenchmarkFolderPath + "/" + TargetAnalyserFile
+
+	WorkspaceAnalyserFile     = "workspace-benchmarks.json"
+	WorkspaceAnalyserFilePath = BenchmarkFolderPath + "/" + WorkspaceAnalyserFile
 )
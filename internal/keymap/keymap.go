@@ -40,6 +40,21 @@ var Quit = key.NewBinding(
 	key.WithHelp("ctrl+(q/c)", "quit"),
 )
 
+var Cancel = key.NewBinding(
+	key.WithKeys("ctrl+x"),
+	key.WithHelp("ctrl+x", "cancel benchmark"),
+)
+
+var Output = key.NewBinding(
+	key.WithKeys("t"),
+	key.WithHelp("t", "toggle output"),
+)
+
+var Logs = key.NewBinding(
+	key.WithKeys("o"),
+	key.WithHelp("o", "logs"),
+)
+
 var Help = key.NewBinding(
 	key.WithKeys("?"),
 	key.WithHelp("?", "help"),
@@ -75,6 +90,16 @@ var TestsAnalyserHistory = key.NewBinding(
 	key.WithHelp("v", "tests analyser history"),
 )
 
+var TargetAnalyserHistory = key.NewBinding(
+	key.WithKeys("b"),
+	key.WithHelp("b", "target analyser history"),
+)
+
+var Rescan = key.NewBinding(
+	key.WithKeys("r"),
+	key.WithHelp("r", "rescan workspace"),
+)
+
 var ListView = key.NewBinding(
 	key.WithKeys("1"),
 	key.WithHelp("1", "list"),
@@ -90,6 +115,21 @@ var JSONView = key.NewBinding(
 	key.WithHelp("3", "json"),
 )
 
+var Files = key.NewBinding(
+	key.WithKeys("f"),
+	key.WithHelp("f", "bundle files"),
+)
+
+var Composition = key.NewBinding(
+	key.WithKeys("p"),
+	key.WithHelp("p", "bundle composition"),
+)
+
+var Compression = key.NewBinding(
+	key.WithKeys("g"),
+	key.WithHelp("g", "raw/gzip/brotli sizes"),
+)
+
 type Model struct {
 	Up         key.Binding
 	Down       key.Binding
@@ -99,6 +139,9 @@ type Model struct {
 	Quit       key.Binding
 	Back       key.Binding
 	Select     key.Binding
+	Cancel     key.Binding
+	Output     key.Binding
+	Logs       key.Binding
 	Search     key.Binding
 	ExitSearch key.Binding
 
@@ -106,10 +149,14 @@ type Model struct {
 	BuildAnalyserHistory  key.Binding
 	LintAnalyserHistory   key.Binding
 	TestsAnalyserHistory  key.Binding
-
-	ListView  key.Binding
-	TableView key.Binding
-	JSONView  key.Binding
+	TargetAnalyserHistory key.Binding
+
+	ListView    key.Binding
+	TableView   key.Binding
+	JSONView    key.Binding
+	Files       key.Binding
+	Composition key.Binding
+	Compression key.Binding
 }
 
 func (k Model) ShortHelp() []key.Binding {
@@ -122,6 +169,7 @@ func (k Model) ShortHelp() []key.Binding {
 		k.ListView,
 		k.TableView,
 		k.JSONView,
+		k.Logs,
 		k.Back,
 		k.Quit,
 		k.Help,
@@ -134,15 +182,22 @@ func (k Model) AllBindings() []key.Binding {
 		k.Down,
 		k.Left,
 		k.Right,
+		k.Cancel,
+		k.Output,
+		k.Logs,
 		k.Search,
 		k.ExitSearch,
 		k.BundleAnalyserHistory,
 		k.BuildAnalyserHistory,
 		k.LintAnalyserHistory,
 		k.TestsAnalyserHistory,
+		k.TargetAnalyserHistory,
 		k.ListView,
 		k.TableView,
 		k.JSONView,
+		k.Files,
+		k.Composition,
+		k.Compression,
 		k.Back,
 		k.Quit,
 		k.Help,
@@ -206,6 +261,7 @@ var historyKeyMap = Model{
 	ListView:  ListView,
 	TableView: TableView,
 	JSONView:  JSONView,
+	Logs:      Logs,
 }
 
 var HistoryKeyMap = CombineKeys(DefaultKeyMap, historyKeyMap)
@@ -9,3 +9,16 @@ func Dispatch(msg tea.Msg) tea.Cmd {
 		return msg
 	}
 }
+
+// Listen returns a command that waits for the next value sent on the channel.
+// The done message is returned once the channel is closed.
+func Listen[T any](ch <-chan T, done tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		value, ok := <-ch
+		if !ok {
+			return done
+		}
+
+		return value
+	}
+}
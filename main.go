@@ -1,7 +1,15 @@
 package main
 
-import "github.com/ionut-t/gonx/program"
+import (
+	"github.com/ionut-t/gonx/cli"
+	"github.com/ionut-t/gonx/program"
+	"os"
+)
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	program.New()
 }
@@ -1,12 +1,15 @@
 package program
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ionut-t/gonx/benchmark"
+	"github.com/ionut-t/gonx/benchmark/runner"
+	"github.com/ionut-t/gonx/internal/messages"
 	"github.com/ionut-t/gonx/ui/styles"
 	"github.com/ionut-t/gonx/ui/suspense"
 	"github.com/ionut-t/gonx/workspace"
@@ -22,10 +25,13 @@ const (
 )
 
 type Model struct {
+	ctx       context.Context
 	view      view
 	suspense  suspense.Model
 	workspace workspace.Model
 	benchmark benchmark.Model
+	// scanning is set while the workspace is scanned again behind the benchmark view
+	scanning bool
 
 	error error
 
@@ -34,19 +40,39 @@ type Model struct {
 }
 
 func (m Model) Init() tea.Cmd {
+	scan := scanWorkspace
+
+	// the workspace of the last session is shown right away and scanned again if it changed since
+	if ws, fingerprint, err := workspace.Cached(); err == nil {
+		scan = tea.Sequence(messages.Dispatch(workspace.DoneMsg{Workspace: *ws}), revalidate(fingerprint))
+	}
+
 	return tea.Batch(
-		func() tea.Msg {
-			ws, err := workspace.New()
-			if err != nil {
-				return workspace.ErrMsg{Err: err}
-			}
-			return workspace.DoneMsg{Workspace: *ws}
-		},
+		scan,
 		m.suspense.Init(),
 		tea.SetWindowTitle("gonx"),
 	)
 }
 
+func scanWorkspace() tea.Msg {
+	ws, err := workspace.New()
+	if err != nil {
+		return workspace.ErrMsg{Err: err}
+	}
+	return workspace.DoneMsg{Workspace: *ws}
+}
+
+// revalidate asks for a rescan when the workspace changed since the cached scan.
+func revalidate(fingerprint string) tea.Cmd {
+	return func() tea.Msg {
+		if workspace.Fresh(fingerprint) {
+			return nil
+		}
+
+		return workspace.RescanMsg{}
+	}
+}
+
 func (m Model) View() string {
 	if m.error != nil {
 		err := m.error.Error()
@@ -84,15 +110,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case workspace.DoneMsg:
 		m.workspace = msg.Workspace
-		m.view = benchmarkView
+		m.scanning = false
+
+		// a rescan only updates the workspace of the benchmark view
+		if m.view != benchmarkView {
+			m.view = benchmarkView
+
+			m.benchmark = benchmark.New(benchmark.Options{
+				Context:   m.ctx,
+				Workspace: m.workspace,
+				Width:     m.width,
+				Height:    m.height,
+			})
+		}
 
-		m.benchmark = benchmark.New(benchmark.Options{
-			Workspace: m.workspace,
-			Width:     m.width,
-			Height:    m.height,
-		})
+	case workspace.RescanMsg:
+		if !m.scanning {
+			m.scanning = true
+			cmds = append(cmds, scanWorkspace)
+		}
 
 	case workspace.ErrMsg:
+		m.scanning = false
+		if m.view == benchmarkView {
+			break
+		}
+
 		m.suspense.Loading = false
 		m.error = errors.New("no Nx workspace found. Please run this command in the root of your Nx workspace")
 		return m, nil
@@ -121,12 +164,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func New() {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	program := Model{
+		ctx:      ctx,
 		view:     suspenseView,
 		suspense: suspense.New("Scanning workspace...", true),
 	}
 
-	if _, err := tea.NewProgram(program, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run(); err != nil {
+	_, err := tea.NewProgram(program, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+
+	// stop the benchmark in progress, if any, so that no nx process outlives gonx
+	cancel()
+	runner.Wait()
+
+	if err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,219 @@
+package logs
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	data "github.com/ionut-t/gonx/benchmark/data"
+	"github.com/ionut-t/gonx/internal/keymap"
+	"github.com/ionut-t/gonx/internal/messages"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/ui/viewport"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+const title = "📜 Logs"
+
+var (
+	itemStyle        = lipgloss.NewStyle().PaddingLeft(4)
+	currentItemStyle = styles.Accent.PaddingLeft(2)
+)
+
+// Entry is a run whose output was captured to a log file.
+type Entry struct {
+	Title  string
+	Path   string
+	Failed bool
+}
+
+func (e Entry) FilterValue() string { return e.Title }
+
+// Runs returns the entries of the recorded runs whose output was captured, titled after their benchmark.
+// Only the runs that failed are marked, the cancelled ones having an error too.
+func Runs(title string, runs []data.Run) []Entry {
+	var entries []Entry
+
+	for i, run := range runs {
+		if run.Log == "" {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Title:  fmt.Sprintf("%s · run %d/%d", title, i+1, len(runs)),
+			Path:   run.Log,
+			Failed: run.Status == data.RunFailed,
+		})
+	}
+
+	return entries
+}
+
+// CloseMsg is sent when the user leaves the log viewer.
+type CloseMsg struct{}
+
+type view int
+
+const (
+	listView view = iota
+	contentView
+)
+
+type Model struct {
+	view     view
+	list     list.Model
+	viewport viewport.Model
+	entry    Entry
+	width    int
+	height   int
+}
+
+// New creates a log viewer listing the given entries, with the cursor on the first failed run.
+func New(entries []Entry, width, height int) Model {
+	items := make([]list.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = entry
+	}
+
+	logsList := list.New(items, itemDelegate{}, width, height-lipgloss.Height(styles.Header(title)))
+	logsList.SetShowTitle(false)
+	logsList.SetShowStatusBar(false)
+	logsList.SetFilteringEnabled(false)
+	logsList.SetShowHelp(false)
+	logsList.InfiniteScrolling = true
+	logsList.KeyMap = list.KeyMap{
+		CursorUp:   keymap.Up,
+		CursorDown: keymap.Down,
+	}
+
+	if idx := slices.IndexFunc(entries, func(e Entry) bool { return e.Failed }); idx != -1 {
+		logsList.Select(idx)
+	}
+
+	return Model{
+		list:   logsList,
+		width:  width,
+		height: height,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.list.SetSize(m.width, m.height-lipgloss.Height(styles.Header(title)))
+		if m.view == contentView {
+			m.open(m.entry)
+		}
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keymap.Back):
+			if m.view == contentView {
+				m.view = listView
+				return m, nil
+			}
+
+			return m, messages.Dispatch(CloseMsg{})
+
+		case key.Matches(msg, keymap.Select):
+			if m.view == listView {
+				if entry, ok := m.list.SelectedItem().(Entry); ok {
+					m.open(entry)
+				}
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+
+	if m.view == contentView {
+		var viewportModel tea.Model
+		viewportModel, cmd = m.viewport.Update(msg)
+		m.viewport = viewportModel.(viewport.Model)
+	} else {
+		m.list, cmd = m.list.Update(msg)
+	}
+
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.view == contentView {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.Header(title, m.entry.Title),
+			m.viewport.View(),
+		)
+	}
+
+	if len(m.list.Items()) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.Header(title),
+			lipgloss.NewStyle().Padding(0, 2).Render(styles.DimText.Render("No logs were recorded.")),
+		)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.Header(title),
+		m.list.View(),
+	)
+}
+
+func (m *Model) open(entry Entry) {
+	m.entry = entry
+	m.view = contentView
+
+	content, err := os.ReadFile(entry.Path)
+
+	output := string(content)
+	if err != nil {
+		output = styles.Error.Render(fmt.Sprintf("Failed to read %s: %v", entry.Path, err))
+	} else if strings.TrimSpace(output) == "" {
+		output = styles.DimText.Render("The run produced no output.")
+	}
+
+	m.viewport = viewport.New(viewport.Options{
+		Width:   m.width,
+		Height:  m.height - lipgloss.Height(styles.Header(title, entry.Title)),
+		Content: lipgloss.NewStyle().Padding(0, 2).Render(output),
+	})
+}
+
+type itemDelegate struct{}
+
+func (d itemDelegate) Height() int                             { return 1 }
+func (d itemDelegate) Spacing() int                            { return 0 }
+func (d itemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	entry, ok := listItem.(Entry)
+	if !ok {
+		return
+	}
+
+	str := entry.Title
+	if entry.Failed {
+		str += " " + styles.Error.Render("(failed)")
+	}
+
+	fn := itemStyle.Render
+	if index == m.Index() {
+		fn = func(s ...string) string {
+			return currentItemStyle.Render("> " + strings.Join(s, " "))
+		}
+	}
+
+	_, _ = fmt.Fprint(w, fn(str))
+}
@@ -0,0 +1,118 @@
+package tail
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ionut-t/gonx/ui/styles"
+	"github.com/ionut-t/gonx/ui/viewport"
+	"strings"
+)
+
+const padding = 2
+
+// limit is the number of lines kept from the output of the running process.
+const limit = 500
+
+// Model follows the last lines written by the running nx process, under the status of the benchmark.
+type Model struct {
+	viewport viewport.Model
+	title    string
+	lines    []string
+	hidden   bool
+	// interleaved is set while the workers of a parallel benchmark write to the output at the same time
+	interleaved bool
+	width       int
+	height      int
+}
+
+// New creates the output taking the height left under the status.
+func New(status string, width, height int) Model {
+	m := Model{}
+	m.SetSize(status, width, height)
+
+	return m
+}
+
+func (m Model) View() string {
+	if m.hidden {
+		return ""
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Padding(0, 1).Render(styles.Primary.Bold(true).Render(m.title)),
+		m.viewport.View(),
+	)
+}
+
+// Under renders the output, unless it is hidden, under the status.
+func (m Model) Under(status string) string {
+	if m.hidden {
+		return status
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, status, m.View())
+}
+
+// Reset clears the output when a new process is started.
+func (m *Model) Reset(title string) {
+	m.title = title
+	m.lines = nil
+	m.interleaved = false
+	m.viewport.Follow("")
+}
+
+// Interleave clears the output when the workers of a parallel benchmark start,
+// their lines being prefixed with the project they were written by.
+func (m *Model) Interleave(title string) {
+	m.Reset(title)
+	m.interleaved = true
+}
+
+// Push appends the lines written by the process of the project, the output being rendered once for all of them.
+func (m *Model) Push(project string, lines ...string) {
+	for _, line := range lines {
+		if m.interleaved {
+			line = fmt.Sprintf("[%s] %s", project, line)
+		}
+
+		m.lines = append(m.lines, line)
+	}
+
+	if len(m.lines) > limit {
+		m.lines = m.lines[len(m.lines)-limit:]
+	}
+
+	m.viewport.Follow(m.content())
+}
+
+// SetSize fits the output in the height left under the status.
+func (m *Model) SetSize(status string, width, height int) {
+	height -= lipgloss.Height(status)
+	m.width = width
+	m.height = height
+
+	m.viewport = viewport.New(viewport.Options{
+		Width:   width,
+		Height:  max(0, height-1), // the title takes a line
+		Content: m.content(),
+	})
+	m.viewport.Follow(m.content())
+}
+
+// Toggle shows or hides the output.
+func (m *Model) Toggle() {
+	m.hidden = !m.hidden
+}
+
+func (m Model) Hidden() bool {
+	return m.hidden
+}
+
+func (m Model) content() string {
+	// long lines are cut rather than wrapped, so that the newest line is always visible
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		MaxWidth(max(0, m.width-padding)).
+		Render(styles.DimText.Render(strings.Join(m.lines, "\n")))
+}
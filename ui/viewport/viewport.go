@@ -91,3 +91,9 @@ func (m *Model) SetContent(content string) {
 	m.viewport.GotoTop()
 	m.viewport.SetContent(content)
 }
+
+// Follow replaces the content and scrolls to its end, e.g. to tail the output of a process.
+func (m *Model) Follow(content string) {
+	m.viewport.SetContent(content)
+	m.viewport.GotoBottom()
+}
@@ -0,0 +1,85 @@
+package workers
+
+import (
+	"fmt"
+	"github.com/ionut-t/gonx/ui/styles"
+	"strings"
+	"time"
+)
+
+type status struct {
+	message string
+	since   time.Time
+	idle    bool
+}
+
+// Model shows what every worker of a parallel benchmark is busy with.
+type Model struct {
+	statuses []status
+}
+
+// New creates the statuses of the given number of workers, all of them waiting for a project.
+func New(workers int) Model {
+	statuses := make([]status, max(1, workers))
+	for i := range statuses {
+		statuses[i] = status{message: "Waiting for a project", since: time.Now()}
+	}
+
+	return Model{statuses: statuses}
+}
+
+// Parallel reports whether there is more than one worker, the status of a single worker
+// being shown by the spinner of the benchmark.
+func (m Model) Parallel() bool {
+	return len(m.statuses) > 1
+}
+
+// Count returns the number of workers.
+func (m Model) Count() int {
+	return len(m.statuses)
+}
+
+// Message is shown by the spinner of a parallel benchmark, the workers showing the runs in progress.
+func (m Model) Message() string {
+	return fmt.Sprintf("Benchmarking %d projects at a time", len(m.statuses))
+}
+
+// Set updates the status of the worker, numbered from 1.
+func (m *Model) Set(worker int, message string) {
+	if worker < 1 || worker > len(m.statuses) {
+		return
+	}
+
+	m.statuses[worker-1] = status{message: message, since: time.Now()}
+}
+
+// Idle marks the worker as idle, between two runs or once it has no project left.
+func (m *Model) Idle(worker int) {
+	if worker < 1 || worker > len(m.statuses) {
+		return
+	}
+
+	m.statuses[worker-1] = status{message: "Idle", since: time.Now(), idle: true}
+}
+
+func (m Model) View() string {
+	lines := make([]string, len(m.statuses))
+
+	for i, s := range m.statuses {
+		label := styles.DimText.Render(fmt.Sprintf("Worker %d", i+1))
+
+		if s.idle {
+			lines[i] = fmt.Sprintf("%s  %s", label, styles.DimText.Render(s.message))
+			continue
+		}
+
+		lines[i] = fmt.Sprintf(
+			"%s  %s %s",
+			label,
+			s.message,
+			styles.DimText.Render(fmt.Sprintf("%.0fs", time.Since(s.since).Seconds())),
+		)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -157,7 +157,7 @@ func Filter[T any](slice []T, f func(T) bool) []T {
 }
 
 func Errorf(format string, a ...any) error {
-	return fmt.Errorf(format, a)
+	return fmt.Errorf(format, a...)
 }
 
 // Ternary is a generic function that simulates the ternary operator.
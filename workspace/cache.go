@@ -0,0 +1,127 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ionut-t/gonx/internal/constants"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// cacheVersion is bumped whenever the persisted workspace changes shape, so that older caches are ignored.
+const cacheVersion = 3
+
+// fingerprintFiles are the files of the workspace root whose changes can add, remove or reconfigure projects.
+var fingerprintFiles = []string{"nx.json", "package.json", "package-lock.json", "pnpm-lock.yaml", "yarn.lock"}
+
+// skippedFolders hold no project.json of the workspace, or so many files that walking them would defeat the cache.
+var skippedFolders = []string{"node_modules", ".git", ".nx", constants.Folder, ".angular", "dist", "tmp", "coverage"}
+
+type cachedWorkspace struct {
+	Version     int       `json:"version"`
+	Fingerprint string    `json:"fingerprint"`
+	ScannedAt   time.Time `json:"scannedAt"`
+	Workspace   Model     `json:"workspace"`
+}
+
+// Cached returns the workspace persisted by the last scan, without checking whether it is still up to date,
+// along with the fingerprint of the workspace it was scanned from.
+func Cached() (*Model, string, error) {
+	content, err := os.ReadFile(constants.WorkspaceCacheFilePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var cache cachedWorkspace
+	if err := json.Unmarshal(content, &cache); err != nil {
+		return nil, "", err
+	}
+
+	if cache.Version != cacheVersion {
+		return nil, "", fmt.Errorf("the workspace cache has version %d instead of %d", cache.Version, cacheVersion)
+	}
+
+	return &cache.Workspace, cache.Fingerprint, nil
+}
+
+// Load returns the cached workspace when it is up to date, scanning the workspace otherwise.
+func Load() (*Model, error) {
+	if workspace, fingerprint, err := Cached(); err == nil && Fresh(fingerprint) {
+		return workspace, nil
+	}
+
+	return New()
+}
+
+// Fresh reports whether the workspace is unchanged since the given fingerprint was taken.
+func Fresh(fingerprint string) bool {
+	current, err := Fingerprint()
+	return err == nil && current == fingerprint
+}
+
+// Fingerprint hashes the size and the modification time of the root configuration files,
+// the lock files and every project.json of the workspace.
+func Fingerprint() (string, error) {
+	digest := sha256.New()
+	_, _ = fmt.Fprintf(digest, "version %d\n", cacheVersion)
+
+	for _, file := range fingerprintFiles {
+		writeFileStat(digest, file)
+	}
+
+	err := filepath.WalkDir(".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// an unreadable folder is left out rather than failing the whole fingerprint
+			return nil
+		}
+
+		if entry.IsDir() && path != "." && slices.Contains(skippedFolders, entry.Name()) {
+			return filepath.SkipDir
+		}
+
+		if !entry.IsDir() && entry.Name() == "project.json" {
+			writeFileStat(digest, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+func writeFileStat(digest hash.Hash, path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		_, _ = fmt.Fprintf(digest, "%s missing\n", path)
+		return
+	}
+
+	_, _ = fmt.Fprintf(digest, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+}
+
+func writeCache(workspace Model, fingerprint string) error {
+	content, err := json.Marshal(cachedWorkspace{
+		Version:     cacheVersion,
+		Fingerprint: fingerprint,
+		ScannedAt:   time.Now(),
+		Workspace:   workspace,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(constants.Folder, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(constants.WorkspaceCacheFilePath, content, 0644)
+}
@@ -0,0 +1,91 @@
+package workspace
+
+import "slices"
+
+// Graph indexes the dependencies between the projects of the workspace in both directions.
+type Graph struct {
+	projects   map[string]Project
+	dependents map[string][]string
+}
+
+// Graph returns the dependency graph of the projects of the workspace.
+func (m Model) Graph() Graph {
+	graph := Graph{
+		projects:   make(map[string]Project),
+		dependents: make(map[string][]string),
+	}
+
+	for _, project := range m.GetProjects([]ProjectType{ApplicationType, LibraryType, E2EType}) {
+		graph.projects[project.GetName()] = project
+
+		for _, dependency := range project.GetDependencies() {
+			graph.dependents[dependency] = append(graph.dependents[dependency], project.GetName())
+		}
+	}
+
+	for _, dependents := range graph.dependents {
+		slices.Sort(dependents)
+	}
+
+	return graph
+}
+
+// Project returns the project of the workspace with the given name.
+func (g Graph) Project(name string) (Project, bool) {
+	project, ok := g.projects[name]
+	return project, ok
+}
+
+// Dependencies returns the names of the projects the given project depends on directly.
+func (g Graph) Dependencies(name string) []string {
+	project, ok := g.projects[name]
+	if !ok {
+		return nil
+	}
+
+	return project.GetDependencies()
+}
+
+// Dependents returns the sorted names of the projects depending directly on the given project.
+func (g Graph) Dependents(name string) []string {
+	return g.dependents[name]
+}
+
+// TransitiveDependencies returns the sorted names of the projects the given project depends on,
+// directly or through other projects.
+func (g Graph) TransitiveDependencies(name string) []string {
+	return walkGraph(name, g.Dependencies)
+}
+
+// TransitiveDependents returns the sorted names of the projects depending on the given project,
+// directly or through other projects, i.e. everything downstream of it.
+func (g Graph) TransitiveDependents(name string) []string {
+	return walkGraph(name, g.Dependents)
+}
+
+// walkGraph returns the projects reachable from the given one, which is left out even when the graph has a cycle.
+func walkGraph(name string, next func(name string) []string) []string {
+	visited := map[string]bool{name: true}
+	queue := []string{name}
+
+	var reached []string
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, project := range next(current) {
+			if visited[project] {
+				continue
+			}
+
+			visited[project] = true
+			reached = append(reached, project)
+			queue = append(queue, project)
+		}
+	}
+
+	slices.Sort(reached)
+
+	return reached
+}
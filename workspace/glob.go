@@ -0,0 +1,36 @@
+package workspace
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchGlob reports whether the name or the folder of the project matches the pattern,
+// a trailing /** matching any folder below the one before it.
+func MatchGlob(pattern string, project Project) bool {
+	if matched, _ := path.Match(pattern, project.GetName()); matched {
+		return true
+	}
+
+	root := project.GetRoot()
+	if root == "" {
+		return false
+	}
+
+	if matched, _ := path.Match(pattern, root); matched {
+		return true
+	}
+
+	parent, ok := strings.CutSuffix(pattern, "/**")
+	if !ok {
+		return false
+	}
+
+	for dir := root; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if matched, _ := path.Match(parent, dir); matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+package workspace
+
+import (
+	"path"
+	"strings"
+)
+
+// outputPathReader extracts the folder a build target writes to from the options of its executor.
+type outputPathReader func(target Target, config ProjectConfig) string
+
+// outputPathReaders are keyed by executor, the @nrwl scope of the older Nx versions being read as @nx.
+var outputPathReaders = map[string]outputPathReader{
+	"@angular-devkit/build-angular:application":     angularOutputPath,
+	"@angular-devkit/build-angular:browser":         angularOutputPath,
+	"@angular-devkit/build-angular:browser-esbuild": angularOutputPath,
+	"@angular/build:application":                    angularOutputPath,
+	"@nx/angular:application":                       angularOutputPath,
+	"@nx/angular:browser-esbuild":                   angularOutputPath,
+	"@nx/angular:webpack-browser":                   angularOutputPath,
+	"@nx/vite:build":                                viteOutputPath,
+	"@nx/webpack:webpack":                           optionOutputPath,
+	"@nx/esbuild:esbuild":                           optionOutputPath,
+}
+
+// buildOutputPath returns the folder the build target of the project writes to, relative to the workspace root.
+// It is empty when the project has no build target or when its output cannot be told.
+func buildOutputPath(config ProjectConfig) string {
+	build, ok := config.Targets["build"]
+	if !ok {
+		return ""
+	}
+
+	executor := strings.Replace(build.Executor, "@nrwl/", "@nx/", 1)
+
+	if reader, ok := outputPathReaders[executor]; ok {
+		if outputPath := reader(build, config); outputPath != "" {
+			return outputPath
+		}
+	}
+
+	// the outputs are the only hint left for the other executors, e.g. the targets inferred by the Nx plugins
+	for _, output := range build.Outputs {
+		if outputPath := interpolateOutput(output, build, config); outputPath != "" {
+			return outputPath
+		}
+	}
+
+	return ""
+}
+
+// angularOutputPath reads the output path of the Angular builders, which can be an object since Angular 17.
+// The browser folder is left to the bundle analyser when it is the default one.
+func angularOutputPath(target Target, _ ProjectConfig) string {
+	switch outputPath := target.Options["outputPath"].(type) {
+	case string:
+		return outputPath
+
+	case map[string]any:
+		base, _ := outputPath["base"].(string)
+		if browser, ok := outputPath["browser"].(string); ok {
+			return path.Join(base, browser)
+		}
+
+		return base
+	}
+
+	return ""
+}
+
+// viteOutputPath falls back to the default output path of the Nx Vite executor.
+func viteOutputPath(target Target, config ProjectConfig) string {
+	if outputPath := target.StringOption("outputPath"); outputPath != "" {
+		return outputPath
+	}
+
+	if config.Root == "" {
+		return ""
+	}
+
+	return path.Join("dist", config.Root)
+}
+
+func optionOutputPath(target Target, _ ProjectConfig) string {
+	return target.StringOption("outputPath")
+}
+
+// interpolateOutput replaces the tokens of an output of the target, e.g. {workspaceRoot}/dist/{projectRoot}.
+// It is empty when a token cannot be replaced.
+func interpolateOutput(output string, target Target, config ProjectConfig) string {
+	replaced := strings.NewReplacer(
+		"{workspaceRoot}/", "",
+		"{workspaceRoot}", ".",
+		"{projectRoot}", config.Root,
+		"{projectName}", config.Name,
+	).Replace(output)
+
+	for strings.Contains(replaced, "{options.") {
+		start := strings.Index(replaced, "{options.")
+		end := strings.Index(replaced[start:], "}")
+		if end < 0 {
+			return ""
+		}
+
+		option := target.StringOption(replaced[start+len("{options.") : start+end])
+		if option == "" {
+			return ""
+		}
+
+		replaced = replaced[:start] + option + replaced[start+end+1:]
+	}
+
+	if strings.ContainsAny(replaced, "{}*") {
+		return ""
+	}
+
+	return path.Clean(replaced)
+}
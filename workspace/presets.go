@@ -0,0 +1,90 @@
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ionut-t/gonx/internal/constants"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Preset is a named selection of projects, reused from the TUI or from the CLI with --preset.
+type Preset struct {
+	Name      string    `json:"name"`
+	Projects  []string  `json:"projects"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Presets returns the saved presets sorted by name, none when no preset was saved yet.
+func Presets() ([]Preset, error) {
+	content, err := os.ReadFile(constants.PresetsFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var presets []Preset
+	if err := json.Unmarshal(content, &presets); err != nil {
+		return nil, err
+	}
+
+	return presets, nil
+}
+
+// FindPreset returns the saved preset with the given name.
+func FindPreset(name string) (Preset, error) {
+	presets, err := Presets()
+	if err != nil {
+		return Preset{}, err
+	}
+
+	idx := slices.IndexFunc(presets, func(preset Preset) bool {
+		return preset.Name == name
+	})
+
+	if idx == -1 {
+		return Preset{}, fmt.Errorf("preset %s not found", name)
+	}
+
+	return presets[idx], nil
+}
+
+// SavePreset saves the projects under the given name, replacing the preset with the same name.
+func SavePreset(name string, projects []string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("the name of the preset is required")
+	}
+
+	presets, err := Presets()
+	if err != nil {
+		return err
+	}
+
+	presets = slices.DeleteFunc(presets, func(preset Preset) bool {
+		return preset.Name == name
+	})
+
+	presets = append(presets, Preset{Name: name, Projects: projects, CreatedAt: time.Now()})
+
+	slices.SortFunc(presets, func(a, b Preset) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	content, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(constants.Folder, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(constants.PresetsFilePath, content, 0644)
+}
@@ -0,0 +1,104 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+)
+
+// projectGraph is the output of nx graph --file, holding the configuration of every project of the workspace.
+type projectGraph struct {
+	Graph struct {
+		Nodes map[string]struct {
+			Name string `json:"name"`
+			// Type is app, lib or e2e
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		} `json:"nodes"`
+		Dependencies map[string][]struct {
+			Source string `json:"source"`
+			Target string `json:"target"`
+		} `json:"dependencies"`
+	} `json:"graph"`
+}
+
+// readProjectGraph discovers all the projects of the workspace with a single nx graph command,
+// instead of an nx process per project.
+func readProjectGraph() ([]projectDetails, error) {
+	dir, err := os.MkdirTemp("", "gonx-graph-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// nx infers the format of the graph from the extension of the file
+	file := filepath.Join(dir, "graph.json")
+
+	output, err := exec.Command("nx", "graph", "--file="+file).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("nx graph failed: %v\nOutput: %s", err, string(output))
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProjectGraph(content)
+}
+
+func parseProjectGraph(content []byte) ([]projectDetails, error) {
+	var graph projectGraph
+	if err := json.Unmarshal(content, &graph); err != nil {
+		return nil, err
+	}
+
+	if len(graph.Graph.Nodes) == 0 {
+		return nil, fmt.Errorf("the project graph has no project")
+	}
+
+	projects := make([]projectDetails, 0, len(graph.Graph.Nodes))
+
+	for name, node := range graph.Graph.Nodes {
+		project, err := newProjectDetails(name, node.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the configuration of project %s: %v", name, err)
+		}
+
+		// the project type is missing from the configuration of the inferred projects
+		project.nodeType = graphNodeType(node.Type)
+
+		for _, dependency := range graph.Graph.Dependencies[name] {
+			// the npm packages are external nodes, not projects of the workspace
+			if _, ok := graph.Graph.Nodes[dependency.Target]; !ok {
+				continue
+			}
+
+			if !slices.Contains(project.dependencies, dependency.Target) {
+				project.dependencies = append(project.dependencies, dependency.Target)
+			}
+		}
+
+		slices.Sort(project.dependencies)
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// graphNodeType translates the type of a node of the project graph into a project type.
+func graphNodeType(nodeType string) ProjectType {
+	switch nodeType {
+	case "app":
+		return ApplicationType
+	case "lib":
+		return LibraryType
+	case "e2e":
+		return E2EType
+	}
+
+	return ProjectType(nodeType)
+}
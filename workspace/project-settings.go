@@ -1,104 +1,136 @@
 package workspace
 
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// ProjectConfig is the configuration of a project, as printed by nx show project --json or held by the project graph.
 type ProjectConfig struct {
-	Name        string  `json:"name"`
-	Schema      string  `json:"$schema"`
-	ProjectType string  `json:"projectType"`
-	Prefix      string  `json:"prefix"`
-	SourceRoot  string  `json:"sourceRoot"`
-	Tags        []any   `json:"tags"`
-	Targets     Targets `json:"targets"`
+	Name        string   `json:"name"`
+	ProjectType string   `json:"projectType"`
+	Root        string   `json:"root"`
+	SourceRoot  string   `json:"sourceRoot"`
+	Tags        []string `json:"tags"`
+	// ImplicitDependencies are the projects the project depends on without importing them, "!" excluding one.
+	ImplicitDependencies []string          `json:"implicitDependencies"`
+	Targets              map[string]Target `json:"targets"`
 }
 
-type Assets struct {
-	Glob  string `json:"glob"`
-	Input string `json:"input"`
+// Target is the raw definition of an Nx target, whose options are only known to its executor.
+type Target struct {
+	Executor             string                    `json:"executor,omitempty"`
+	Outputs              []string                  `json:"outputs,omitempty"`
+	Options              map[string]any            `json:"options,omitempty"`
+	Configurations       map[string]map[string]any `json:"configurations,omitempty"`
+	DefaultConfiguration string                    `json:"defaultConfiguration,omitempty"`
 }
 
-type BuildOptions struct {
-	OutputPath          string   `json:"outputPath"`
-	Index               string   `json:"index"`
-	Browser             string   `json:"browser"`
-	Polyfills           []string `json:"polyfills"`
-	TsConfig            string   `json:"tsConfig"`
-	InlineStyleLanguage string   `json:"inlineStyleLanguage"`
-	Assets              []Assets `json:"assets"`
-	Styles              []string `json:"styles"`
-	Scripts             []any    `json:"scripts"`
+// ConfigurationNames returns the configurations of the target, sorted alphabetically.
+func (t Target) ConfigurationNames() []string {
+	names := make([]string, 0, len(t.Configurations))
+	for name := range t.Configurations {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
 }
-type Budgets struct {
+
+// Budget is a size budget of an Angular build target, its sizes written the way Angular reads them, e.g. 500kb or 10%.
+type Budget struct {
 	Type           string `json:"type"`
-	MaximumWarning string `json:"maximumWarning"`
-	MaximumError   string `json:"maximumError"`
+	Name           string `json:"name,omitempty"`
+	Baseline       string `json:"baseline,omitempty"`
+	MaximumWarning string `json:"maximumWarning,omitempty"`
+	MaximumError   string `json:"maximumError,omitempty"`
+	MinimumWarning string `json:"minimumWarning,omitempty"`
+	MinimumError   string `json:"minimumError,omitempty"`
+	Warning        string `json:"warning,omitempty"`
+	Error          string `json:"error,omitempty"`
 }
 
-type Production struct {
-	Budgets       []Budgets `json:"budgets"`
-	OutputHashing string    `json:"outputHashing"`
-}
+// Budgets returns the budgets of the target in the given configuration, the default configuration when empty.
+// The budgets of a configuration replace the ones of the options, as they do in Angular.
+func (t Target) Budgets(configuration string) []Budget {
+	if configuration == "" {
+		configuration = t.DefaultConfiguration
+	}
 
-type Development struct {
-	Optimization    bool `json:"optimization"`
-	ExtractLicenses bool `json:"extractLicenses"`
-	SourceMap       bool `json:"sourceMap"`
-}
+	raw, ok := t.Configurations[configuration]["budgets"]
+	if !ok {
+		raw, ok = t.Options["budgets"]
+	}
+	if !ok {
+		return nil
+	}
 
-type Configurations struct {
-	Production  Production  `json:"production"`
-	Development Development `json:"development"`
-}
+	content, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
 
-type Build struct {
-	Executor             string         `json:"executor"`
-	Outputs              []string       `json:"outputs"`
-	Options              BuildOptions   `json:"options"`
-	Configurations       Configurations `json:"configurations"`
-	DefaultConfiguration string         `json:"defaultConfiguration"`
-}
-type Serve struct {
-	Executor             string         `json:"executor"`
-	Configurations       Configurations `json:"configurations"`
-	DefaultConfiguration string         `json:"defaultConfiguration"`
-}
+	var budgets []Budget
+	if err := json.Unmarshal(content, &budgets); err != nil {
+		return nil
+	}
 
-type ExtractI18NOptions struct {
-	BuildTarget string `json:"buildTarget"`
-}
-type ExtractI18N struct {
-	Executor string             `json:"executor"`
-	Options  ExtractI18NOptions `json:"options"`
+	return budgets
 }
 
-type Lint struct {
-	Executor string `json:"executor"`
-}
-type TestOptions struct {
-	JestConfig string `json:"jestConfig"`
+// StringOption returns the option of the target with the given name when it is a string.
+func (t Target) StringOption(name string) string {
+	value, _ := t.Options[name].(string)
+	return value
 }
 
-type Test struct {
-	Executor string      `json:"executor"`
-	Outputs  []string    `json:"outputs"`
-	Options  TestOptions `json:"options"`
+// parseProjectConfig reads the configuration of a project, whatever the executors of its targets.
+func parseProjectConfig(content []byte) (ProjectConfig, error) {
+	var config ProjectConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return ProjectConfig{}, err
+	}
+
+	return config, nil
 }
 
-type ServeStaticOptions struct {
-	BuildTarget    string `json:"buildTarget"`
-	Port           int    `json:"port"`
-	StaticFilePath string `json:"staticFilePath"`
-	Spa            bool   `json:"spa"`
+// implicitDependencies returns the projects the configuration depends on implicitly, leaving out the excluded ones.
+func (c ProjectConfig) implicitDependencies() []string {
+	var dependencies []string
+
+	for _, dependency := range c.ImplicitDependencies {
+		if !strings.HasPrefix(dependency, "!") {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	return dependencies
 }
 
-type ServeStatic struct {
-	Executor string             `json:"executor"`
-	Options  ServeStaticOptions `json:"options"`
+// isE2E reports whether the project only runs end-to-end tests, going by the executors of its targets
+// when nx does not tell it apart from an application.
+func (c ProjectConfig) isE2E() bool {
+	if slices.Contains(c.Tags, "type:e2e") {
+		return true
+	}
+
+	if _, ok := c.Targets["build"]; ok {
+		return false
+	}
+
+	e2e, ok := c.Targets["e2e"]
+
+	return ok && isE2EExecutor(e2e.Executor)
 }
 
-type Targets struct {
-	Build       Build       `json:"build"`
-	Serve       Serve       `json:"serve"`
-	ExtractI18N ExtractI18N `json:"extract-i18n"`
-	Lint        Lint        `json:"lint"`
-	Test        Test        `json:"test"`
-	ServeStatic ServeStatic `json:"serve-static"`
+func isE2EExecutor(executor string) bool {
+	for _, prefix := range []string{"@nx/cypress:", "@nx/playwright:", "@nrwl/cypress:", "@nrwl/playwright:"} {
+		if strings.HasPrefix(executor, prefix) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -1,7 +1,6 @@
 package workspace
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -17,6 +16,8 @@ type Model struct {
 	Applications []Application `json:"applications"`
 	Libraries    []Library     `json:"libraries"`
 	E2EApps      []E2EApp      `json:"e2eApps"`
+	// Skipped describes why the projects that could not be read were left out of the workspace.
+	Skipped []string `json:"skipped,omitempty"`
 }
 
 func (m *Model) String() string {
@@ -34,12 +35,29 @@ const (
 type Project interface {
 	GetName() string
 	GetType() ProjectType
+	// GetRoot returns the folder of the project, relative to the workspace root.
+	GetRoot() string
+	GetTags() []string
+	// GetTargets returns the names of the Nx targets of the project, e.g. build or e2e.
+	GetTargets() []string
+	// GetTarget returns the definition of the given target, as configured for the project.
+	GetTarget(name string) (Target, bool)
+	// GetConfigurations returns the configuration names of the given target, e.g. production for build.
+	GetConfigurations(target string) []string
+	// GetDependencies returns the names of the projects of the workspace the project depends on.
+	GetDependencies() []string
 }
 
 type Application struct {
-	Name       string      `json:"name"`
-	OutputPath string      `json:"outputPath"`
-	Type       ProjectType `json:"type"`
+	Name                 string            `json:"name"`
+	OutputPath           string            `json:"outputPath"`
+	Type                 ProjectType       `json:"type"`
+	Root                 string            `json:"root,omitempty"`
+	SourceRoot           string            `json:"sourceRoot,omitempty"`
+	Tags                 []string          `json:"tags,omitempty"`
+	ImplicitDependencies []string          `json:"implicitDependencies,omitempty"`
+	Targets              map[string]Target `json:"targets,omitempty"`
+	Dependencies         []string          `json:"dependencies,omitempty"`
 }
 
 func (a Application) GetName() string {
@@ -50,10 +68,41 @@ func (a Application) GetType() ProjectType {
 	return a.Type
 }
 
+func (a Application) GetRoot() string {
+	return a.Root
+}
+
+func (a Application) GetTags() []string {
+	return a.Tags
+}
+
+func (a Application) GetTargets() []string {
+	return targetNames(a.Targets)
+}
+
+func (a Application) GetTarget(name string) (Target, bool) {
+	target, ok := a.Targets[name]
+	return target, ok
+}
+
+func (a Application) GetDependencies() []string {
+	return a.Dependencies
+}
+
+func (a Application) GetConfigurations(target string) []string {
+	return a.Targets[target].ConfigurationNames()
+}
+
 type Library struct {
-	Name  string      `json:"name"`
-	Suite string      `json:"suite"`
-	Type  ProjectType `json:"type"`
+	Name                 string            `json:"name"`
+	Suite                string            `json:"suite"`
+	Type                 ProjectType       `json:"type"`
+	Root                 string            `json:"root,omitempty"`
+	SourceRoot           string            `json:"sourceRoot,omitempty"`
+	Tags                 []string          `json:"tags,omitempty"`
+	ImplicitDependencies []string          `json:"implicitDependencies,omitempty"`
+	Targets              map[string]Target `json:"targets,omitempty"`
+	Dependencies         []string          `json:"dependencies,omitempty"`
 }
 
 func (l Library) GetName() string {
@@ -64,10 +113,41 @@ func (l Library) GetType() ProjectType {
 	return l.Type
 }
 
+func (l Library) GetRoot() string {
+	return l.Root
+}
+
+func (l Library) GetTags() []string {
+	return l.Tags
+}
+
+func (l Library) GetTargets() []string {
+	return targetNames(l.Targets)
+}
+
+func (l Library) GetTarget(name string) (Target, bool) {
+	target, ok := l.Targets[name]
+	return target, ok
+}
+
+func (l Library) GetDependencies() []string {
+	return l.Dependencies
+}
+
+func (l Library) GetConfigurations(target string) []string {
+	return l.Targets[target].ConfigurationNames()
+}
+
 type E2EApp struct {
-	Name  string      `json:"name"`
-	Suite string      `json:"suite"`
-	Type  ProjectType `json:"type"`
+	Name                 string            `json:"name"`
+	Suite                string            `json:"suite"`
+	Type                 ProjectType       `json:"type"`
+	Root                 string            `json:"root,omitempty"`
+	SourceRoot           string            `json:"sourceRoot,omitempty"`
+	Tags                 []string          `json:"tags,omitempty"`
+	ImplicitDependencies []string          `json:"implicitDependencies,omitempty"`
+	Targets              map[string]Target `json:"targets,omitempty"`
+	Dependencies         []string          `json:"dependencies,omitempty"`
 }
 
 func (e E2EApp) GetName() string {
@@ -78,82 +158,193 @@ func (e E2EApp) GetType() ProjectType {
 	return e.Type
 }
 
-func getAllApps() ([]Application, []Library, []E2EApp, error) {
-	cmd := exec.Command("nx", "show", "projects")
-	output, err := cmd.CombinedOutput()
+func (e E2EApp) GetRoot() string {
+	return e.Root
+}
+
+func (e E2EApp) GetTags() []string {
+	return e.Tags
+}
+
+func (e E2EApp) GetTargets() []string {
+	return targetNames(e.Targets)
+}
+
+func (e E2EApp) GetTarget(name string) (Target, bool) {
+	target, ok := e.Targets[name]
+	return target, ok
+}
+
+func (e E2EApp) GetDependencies() []string {
+	return e.Dependencies
+}
+
+func (e E2EApp) GetConfigurations(target string) []string {
+	return e.Targets[target].ConfigurationNames()
+}
+
+func getAllApps() ([]Application, []Library, []E2EApp, []string, error) {
+	var skipped []string
 
+	projects, err := readProjectGraph()
 	if err != nil {
-		return nil, nil, nil, err
+		// nx graph --file is not available in every version of nx, so each project is shown on its own
+		projects, skipped, err = showProjects()
 	}
 
-	outputStr := string(output)
-	rawApps := strings.Split(strings.TrimSpace(outputStr), "\n")
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
 
-	slices.SortStableFunc(rawApps, func(a, b string) int {
-		return strings.Compare(a, b)
+	slices.SortStableFunc(projects, func(a, b projectDetails) int {
+		return strings.Compare(a.name, b.name)
 	})
 
-	apps, libs, e2eApps := parseApps(rawApps)
+	apps, libs, e2eApps := classifyProjects(projects)
 
-	return apps, libs, e2eApps, nil
+	return apps, libs, e2eApps, skipped, nil
 }
 
-func parseApps(allApps []string) ([]Application, []Library, []E2EApp) {
-	var apps []Application
-	var libs []Library
-	var e2eApps []E2EApp
+// projectDetails holds what was found about a project, whichever way the workspace was discovered.
+type projectDetails struct {
+	name   string
+	config ProjectConfig
+	// nodeType is the type nx gives to the project in the project graph, empty when there is no graph
+	nodeType     ProjectType
+	dependencies []string
+}
+
+// showProjects lists the projects with nx show projects and spawns nx show project for each of them.
+// The projects that cannot be shown or parsed are left out, the reasons being returned along with the other projects.
+func showProjects() ([]projectDetails, []string, error) {
+	cmd := exec.Command("nx", "show", "projects")
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		return nil, nil, err
+	}
 
-	for _, app := range allApps {
+	var (
+		projects []projectDetails
+		skipped  []string
+	)
+
+	for _, app := range strings.Split(strings.TrimSpace(string(output)), "\n") {
 		cmd := exec.Command("nx", "show", "project", app, "--json")
 		output, err := cmd.CombinedOutput()
 
 		if err != nil {
-			fmt.Printf("Failed to show project %s: %v\nOutput: %s", app, err, string(output))
+			skipped = append(skipped, fmt.Sprintf("failed to show project %s: %v: %s", app, err, strings.TrimSpace(string(output))))
 			continue
-		} else {
-			var projectConfig ProjectConfig
-
-			_ = json.Unmarshal(output, &projectConfig)
-
-			// Ignore parsing errors for now since the project configuration it's different for each project
-			// TODO: Find a way to properly parse the project configuration for each project
-			//if parseError != nil {
-			//	fmt.Printf("Failed to parse project config for %s %s", app, parseError)
-			//	continue
-			//}
-			projectType := projectConfig.ProjectType
-			if strings.HasSuffix(app, ".e2e") {
-				continue
-			}
+		}
 
-			if strings.Contains(app, "-e2e") {
-				e2eApps = append(e2eApps, E2EApp{
-					Name: app,
-					Type: E2EType,
-				})
-				continue
-			}
+		project, err := newProjectDetails(app, output)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("failed to parse the configuration of project %s: %v", app, err))
+			continue
+		}
 
-			if ProjectType(projectType) == ApplicationType {
-				apps = append(apps, Application{
-					Name:       app,
-					OutputPath: projectConfig.Targets.Build.Options.OutputPath,
-					Type:       ApplicationType,
-				})
-			}
+		// without the project graph, only the implicit dependencies are known
+		project.dependencies = project.config.implicitDependencies()
+		projects = append(projects, project)
+	}
 
-			if ProjectType(projectType) == LibraryType {
-				libs = append(libs, Library{
-					Name: filepath.Base(app),
-					Type: LibraryType,
-				})
-			}
+	return projects, skipped, nil
+}
+
+// newProjectDetails parses the configuration of a project, as printed by nx show project --json.
+func newProjectDetails(name string, content []byte) (projectDetails, error) {
+	config, err := parseProjectConfig(content)
+	if err != nil {
+		return projectDetails{}, err
+	}
+
+	return projectDetails{name: name, config: config}, nil
+}
+
+// projectType goes by the project type set by nx, the e2e projects being told apart by the project graph,
+// their tags or the executors of their targets.
+func (p projectDetails) projectType() ProjectType {
+	if p.nodeType == E2EType || p.config.isE2E() {
+		return E2EType
+	}
+
+	switch projectType := ProjectType(p.config.ProjectType); projectType {
+	case ApplicationType, LibraryType:
+		return projectType
+	}
+
+	if p.nodeType == ApplicationType {
+		return p.nodeType
+	}
+
+	// like nx, a project without a known project type is a library
+	return LibraryType
+}
+
+func classifyProjects(projects []projectDetails) ([]Application, []Library, []E2EApp) {
+	var apps []Application
+	var libs []Library
+	var e2eApps []E2EApp
+
+	for _, project := range projects {
+		config := project.config
+
+		switch project.projectType() {
+		case E2EType:
+			e2eApps = append(e2eApps, E2EApp{
+				Name:                 project.name,
+				Type:                 E2EType,
+				Root:                 config.Root,
+				SourceRoot:           config.SourceRoot,
+				Tags:                 config.Tags,
+				ImplicitDependencies: config.ImplicitDependencies,
+				Targets:              config.Targets,
+				Dependencies:         project.dependencies,
+			})
+
+		case ApplicationType:
+			apps = append(apps, Application{
+				Name:                 project.name,
+				OutputPath:           buildOutputPath(config),
+				Type:                 ApplicationType,
+				Root:                 config.Root,
+				SourceRoot:           config.SourceRoot,
+				Tags:                 config.Tags,
+				ImplicitDependencies: config.ImplicitDependencies,
+				Targets:              config.Targets,
+				Dependencies:         project.dependencies,
+			})
+
+		case LibraryType:
+			libs = append(libs, Library{
+				Name:                 project.name,
+				Type:                 LibraryType,
+				Root:                 config.Root,
+				SourceRoot:           config.SourceRoot,
+				Tags:                 config.Tags,
+				ImplicitDependencies: config.ImplicitDependencies,
+				Targets:              config.Targets,
+				Dependencies:         project.dependencies,
+			})
 		}
 	}
 
 	return apps, libs, e2eApps
 }
 
+// targetNames returns the names of the targets, sorted alphabetically.
+func targetNames(targets map[string]Target) []string {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
 type DoneMsg struct {
 	Workspace Model
 }
@@ -162,6 +353,10 @@ type ErrMsg struct {
 	Err error
 }
 
+// RescanMsg asks for the workspace to be scanned again, its cache being out of date or a rescan being forced.
+type RescanMsg struct{}
+
+// New scans the workspace and persists it, so that the next sessions can load it instantly.
 func New() (*Model, error) {
 	cwd, err := os.Getwd()
 
@@ -169,11 +364,14 @@ func New() (*Model, error) {
 		return nil, err
 	}
 
+	// the fingerprint is taken before scanning, so that a change made during the scan invalidates the cache
+	fingerprint, fingerprintErr := Fingerprint()
+
 	workspace := Model{
 		Name: filepath.Base(cwd),
 	}
 
-	apps, libs, e2eApps, err := getAllApps()
+	apps, libs, e2eApps, skipped, err := getAllApps()
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +379,12 @@ func New() (*Model, error) {
 	workspace.Applications = apps
 	workspace.Libraries = libs
 	workspace.E2EApps = e2eApps
+	workspace.Skipped = skipped
+
+	// the workspace is scanned again next time when the cache cannot be written
+	if fingerprintErr == nil {
+		_ = writeCache(workspace, fingerprint)
+	}
 
 	return &workspace, nil
 }
@@ -212,3 +416,27 @@ func (m Model) GetProjects(includeTypes []ProjectType) []Project {
 
 	return projects
 }
+
+// GetTargets returns the sorted names of the targets defined by at least one project of the workspace.
+func (m Model) GetTargets() []string {
+	var targets []string
+
+	for _, project := range m.GetProjects([]ProjectType{ApplicationType, LibraryType, E2EType}) {
+		for _, target := range project.GetTargets() {
+			if !slices.Contains(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	slices.Sort(targets)
+
+	return targets
+}
+
+// GetProjectsWithTarget returns the projects of the workspace that define the given target.
+func (m Model) GetProjectsWithTarget(target string) []Project {
+	return utils.Filter(m.GetProjects([]ProjectType{ApplicationType, LibraryType, E2EType}), func(project Project) bool {
+		return slices.Contains(project.GetTargets(), target)
+	})
+}